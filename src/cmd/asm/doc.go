@@ -29,6 +29,8 @@ Flags:
 		dump instructions as they are parsed
 	-dynlink
 		support references to Go symbols defined in other shared libraries
+	-gnu
+		accept GNU assembler syntax (riscv only)
 	-o string
 		output file; default foo.o for /a/b/c/foo.s
 	-shared
@@ -43,6 +45,11 @@ the main variation having to do with addressing modes. Input is
 run through a simplified C preprocessor that implements #include,
 #define, #ifdef/endif, but not #if or ##.
 
+With -gnu, the input is instead RISC-V assembly written for the GNU
+assembler, in which '#' also starts a comment. It is translated into
+the equivalent Go assembly; see cmd/asm/internal/asm/gnu.go for the
+supported directives and the differences from GNU as.
+
 For more information, see https://golang.org/doc/asm.
 */
 package main
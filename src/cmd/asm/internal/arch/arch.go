@@ -499,16 +499,21 @@ func archMips64() *Arch {
 }
 
 var riscvJumps = map[string]bool{
-	"BEQ":  true,
-	"BNE":  true,
-	"BLT":  true,
-	"BGE":  true,
-	"BLTU": true,
-	"BGEU": true,
-	"CALL": true,
-	"JAL":  true,
-	"JALR": true,
-	"JMP":  true,
+	"BEQ":   true,
+	"BNE":   true,
+	"BLT":   true,
+	"BGE":   true,
+	"BLTU":  true,
+	"BGEU":  true,
+	"CALL":  true,
+	"CJ":    true,
+	"CJR":   true,
+	"CJALR": true,
+	"CBEQZ": true,
+	"CBNEZ": true,
+	"JAL":   true,
+	"JALR":  true,
+	"JMP":   true,
 }
 
 func archRiscv() *Arch {
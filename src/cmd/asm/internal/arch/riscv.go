@@ -0,0 +1,45 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file encapsulates some of the odd characteristics of the RISC-V
+// instruction set, to minimize its interaction with the core of the
+// assembler.
+
+package arch
+
+import (
+	"strings"
+
+	"cmd/internal/obj"
+	"cmd/internal/obj/riscv"
+)
+
+// RISCVSuffix handles the rounding mode suffix for RISC-V floating-point
+// instructions, as in FCVTLD.RTZ. It returns a boolean to indicate success;
+// failure means cond was unrecognized.
+func RISCVSuffix(prog *obj.Prog, cond string) bool {
+	if cond == "" {
+		return true
+	}
+	rm, ok := riscv.RoundingModes[strings.TrimPrefix(cond, ".")]
+	if !ok {
+		return false
+	}
+	prog.Scond = rm
+	return true
+}
+
+// RISCVCompressed reassembles the mnemonic of an explicitly compressed
+// RISC-V instruction, which the parser splits at the dot like a suffix:
+// C.ADDI becomes CADDI. Any remaining suffix is returned as the new cond.
+func RISCVCompressed(word, cond string) (string, string) {
+	if word != "C" || cond == "" {
+		return word, cond
+	}
+	name, rest := cond[1:], ""
+	if i := strings.Index(name, "."); i >= 0 {
+		name, rest = name[:i], name[i:]
+	}
+	return word + name, rest
+}
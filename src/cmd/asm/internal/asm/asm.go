@@ -37,6 +37,12 @@ func (p *Parser) append(prog *obj.Prog, cond string, doLabel bool) {
 				return
 			}
 
+		case sys.RISCV:
+			if !arch.RISCVSuffix(prog, cond) {
+				p.errorf("unrecognized rounding mode .%q", cond)
+				return
+			}
+
 		default:
 			p.errorf("unrecognized suffix .%q", cond)
 			return
@@ -19,6 +19,8 @@ import (
 
 	"cmd/asm/internal/lex"
 	"cmd/internal/obj"
+	"cmd/internal/obj/riscv"
+	"cmd/internal/sys"
 )
 
 // An end-to-end test for the assembler: Do we print what we parse?
@@ -254,7 +256,7 @@ func isHexes(s string) bool {
 // the standard file:line: prefix,
 // but that's not where we are today.
 // It might be at the beginning but it might be in the middle of the printed instruction.
-var fileLineRE = regexp.MustCompile(`(?:^|\()(testdata[/\\][0-9a-z]+\.s:[0-9]+)(?:$|\))`)
+var fileLineRE = regexp.MustCompile(`(?:^|\()(testdata[/\\][0-9a-z]+\.s:[0-9]+)(?:$|\)|:)`)
 
 // Same as in test/run.go
 var (
@@ -274,6 +276,7 @@ func testErrors(t *testing.T, goarch, file string) {
 	defer ctxt.Bso.Flush()
 	failed := false
 	var errBuf bytes.Buffer
+	parser.errorWriter = &errBuf
 	ctxt.DiagFunc = func(format string, args ...interface{}) {
 		failed = true
 		s := fmt.Sprintf(format, args...)
@@ -390,9 +393,53 @@ func TestPPC64EndToEnd(t *testing.T) {
 	testEndToEnd(t, "ppc64", "ppc64")
 }
 
+// setRISCVRVC makes the riscv assembler use the C extension or not,
+// whatever GORISCV the toolchain was built with, and returns a func
+// that undoes it. The riscv testdata expects compressed encodings.
+func setRISCVRVC(rvc bool) func() {
+	oldRVC, oldMinLC := riscv.GORISCVRVC, sys.ArchRISCV.MinLC
+	riscv.GORISCVRVC = rvc
+	sys.ArchRISCV.MinLC = 4
+	if rvc {
+		sys.ArchRISCV.MinLC = 2
+	}
+	return func() {
+		riscv.GORISCVRVC, sys.ArchRISCV.MinLC = oldRVC, oldMinLC
+	}
+}
+
 func TestRISCVEncoder(t *testing.T) {
+	defer setRISCVRVC(true)()
 	testEndToEnd(t, "riscv", "riscvenc")
 	testEndToEnd(t, "riscv", "riscvfarbranch")
+	testEndToEnd(t, "riscv", "riscvcompress")
+}
+
+func TestRISCVErrors(t *testing.T) {
+	defer setRISCVRVC(true)()
+	testErrors(t, "riscv", "riscverror")
+}
+
+func TestRISCVTLS(t *testing.T) {
+	// LUI $tlsvar, T6; ADDIW $tlsvar, T6, T6 with an R_RISCV_TLS_LE
+	// relocation covering both. The rest is compressed only with RVC.
+	tests := []struct {
+		rvc  bool
+		code string
+	}{
+		{true, "b70f00009b8f0f00929f03b50f008280"},
+		{false, "b70f00009b8f0f00b38f4f0003b50f0067800000"},
+	}
+	for _, test := range tests {
+		func() {
+			defer setRISCVRVC(test.rvc)()
+			want := fmt.Sprintf("tls: %s 0:8:%d:tlsvar+0", test.code, obj.R_RISCV_TLS_LE)
+			code := assemble(t, "riscv", "riscvtls", false)
+			if len(code) != 1 || code[0] != want {
+				t.Errorf("rvc=%v: have %q, want %q", test.rvc, code, want)
+			}
+		}()
+	}
 }
 
 func TestS390XEndToEnd(t *testing.T) {
@@ -0,0 +1,888 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements the -gnu input mode, which accepts RISC-V assembly
+// written for the GNU assembler.
+//
+// Each GNU instruction is rewritten into the equivalent Go assembly (the
+// destination moved last, registers renamed, immediates marked with $) and
+// then assembled as usual, so it produces the same Progs a hand-written Go
+// port would. The differences from GNU as are:
+//
+// A symbol declared with .globl or .type sym, @function starts a function
+// when its label is defined. The function is NOSPLIT|NOFRAME with a zero
+// frame size, so the code runs exactly as written. Global symbols are
+// package-local Go symbols (memcpy is ·memcpy), other functions are static
+// (memcpy<>), and symbols containing a dot are Go names taken as is
+// (runtime.memmove). Instructions must be inside a function.
+//
+// Labels, including numeric labels such as 1: and 1b, are scoped to the
+// function, as in Go.
+//
+// %hi and %lo are assembled as a PC-relative AUIPC pair, like %pcrel_hi and
+// %pcrel_lo. Either way, the instruction using %lo or %pcrel_lo must
+// immediately follow the AUIPC or LUI it refers to.
+//
+// Only text sections are supported, and alignment only before a function.
+
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"cmd/asm/internal/lex"
+	"cmd/internal/obj"
+	"cmd/internal/obj/riscv"
+)
+
+// gnuFuncAlign is the alignment of every function.
+const gnuFuncAlign = 8
+
+// gnuState is the state of a Parser in GNU mode.
+type gnuState struct {
+	globals   map[string]bool        // Symbols declared with .globl.
+	functions map[string]bool        // Symbols declared with .type sym, @function.
+	equates   map[string][]lex.Token // Values of .equ and .set symbols.
+	numLabels map[string]int         // Number of definitions of each numeric label so far.
+	options   []bool                 // Saved norvc states from .option push.
+	norvc     bool                   // .option norvc is in effect.
+	align     int64                  // Alignment requested for the next function.
+	inText    bool                   // A function has been started.
+	hi        *obj.Prog              // AUIPC for a %hi or %pcrel_hi awaiting its %lo or %pcrel_lo.
+	hiOp      string                 // Relocation operator used by hi.
+}
+
+func newGNUState() *gnuState {
+	return &gnuState{
+		globals:   make(map[string]bool),
+		functions: make(map[string]bool),
+		equates:   make(map[string][]lex.Token),
+		numLabels: make(map[string]int),
+	}
+}
+
+// A gnuToken is a token plus whether it immediately follows the previous
+// token, which distinguishes j .L1 from j.L1 and 1 b from 1b.
+type gnuToken struct {
+	lex.Token
+	glued bool
+}
+
+// gnuLine is line for GNU syntax.
+func (p *Parser) gnuLine() bool {
+	// Skip newlines.
+	var tok lex.ScanToken
+	for {
+		tok = p.lex.Next()
+		p.lineNum = p.lex.Line()
+		switch tok {
+		case '\n', ';':
+			continue
+		case scanner.EOF:
+			p.gnuCheckHi()
+			return false
+		}
+		break
+	}
+	var toks []gnuToken
+	prevCol := -1
+	for tok != '\n' && tok != ';' && tok != scanner.EOF {
+		text := p.lex.Text()
+		col := p.lex.Col()
+		toks = append(toks, gnuToken{lex.Make(tok, text), col-len(text) == prevCol})
+		prevCol = col
+		tok = p.lex.Next()
+	}
+
+	// Zero or more labels.
+	for {
+		if len(toks) >= 2 && toks[0].ScanToken == scanner.Int && toks[1].ScanToken == ':' {
+			num := toks[0].String()
+			p.gnu.numLabels[num]++
+			p.pendingLabels = append(p.pendingLabels, gnuNumLabel(num, p.gnu.numLabels[num]))
+			toks = toks[2:]
+			continue
+		}
+		name, n := gnuName(toks)
+		if n == 0 || n >= len(toks) || toks[n].ScanToken != ':' {
+			break
+		}
+		p.gnuLabel(name)
+		toks = toks[n+1:]
+	}
+	if len(toks) == 0 {
+		return true
+	}
+
+	// A directive or an instruction.
+	if toks[0].ScanToken == scanner.Float && len(toks) > 1 && toks[1].ScanToken == scanner.Ident && toks[1].glued {
+		// .4byte scans as .4 byte.
+		return p.gnuDirective(toks[0].String()+toks[1].String(), gnuSplit(toks[2:]))
+	}
+	if toks[0].ScanToken == '.' {
+		if len(toks) < 2 || toks[1].ScanToken != scanner.Ident || !toks[1].glued {
+			p.errorf("expected directive name after '.'")
+			return true
+		}
+		return p.gnuDirective("."+toks[1].String(), gnuSplit(toks[2:]))
+	}
+	name, n := gnuName(toks)
+	if n == 0 {
+		p.errorf("expected instruction or directive, found %s", toks[0])
+		return true
+	}
+	p.gnuInstruction(strings.ToLower(name), gnuSplit(toks[n:]))
+	return true
+}
+
+// gnuName returns the name at the start of toks, which may contain and
+// begin with dots, and the number of tokens it occupies.
+func gnuName(toks []gnuToken) (string, int) {
+	i := 0
+	if len(toks) > 1 && toks[0].ScanToken == '.' {
+		i++
+	}
+	if i >= len(toks) || toks[i].ScanToken != scanner.Ident || i > 0 && !toks[i].glued {
+		return "", 0
+	}
+	i++
+	for i+1 < len(toks) && toks[i].ScanToken == '.' && toks[i].glued &&
+		toks[i+1].ScanToken == scanner.Ident && toks[i+1].glued {
+		i += 2
+	}
+	var name string
+	for _, tok := range toks[:i] {
+		name += tok.String()
+	}
+	return name, i
+}
+
+// gnuNumLabel returns the Go label for the nth definition of numeric label
+// num.
+func gnuNumLabel(num string, n int) string {
+	return fmt.Sprintf("%s.%d", num, n)
+}
+
+// gnuSplit splits toks into comma-separated operands.
+func gnuSplit(toks []gnuToken) [][]gnuToken {
+	var ops [][]gnuToken
+	if len(toks) == 0 {
+		return ops
+	}
+	nesting, start := 0, 0
+	for i, tok := range toks {
+		switch tok.ScanToken {
+		case '(':
+			nesting++
+		case ')':
+			nesting--
+		case ',':
+			if nesting == 0 {
+				ops = append(ops, toks[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(ops, toks[start:])
+}
+
+// gnuText returns the source text of an operand.
+func gnuText(op []gnuToken) string {
+	var s string
+	for _, tok := range op {
+		s += tok.String()
+	}
+	return s
+}
+
+// gnuLabel defines label name, starting a function if name is one.
+func (p *Parser) gnuLabel(name string) {
+	if !p.gnuIsFunction(name) {
+		p.pendingLabels = append(p.pendingLabels, name)
+		return
+	}
+	p.gnuCheckHi()
+	if p.gnu.align > gnuFuncAlign {
+		p.errorf("function %s: alignment %d is larger than %d", name, p.gnu.align, gnuFuncAlign)
+	}
+	p.gnu.align = 0
+	p.asmText("TEXT", [][]lex.Token{
+		p.gnuSymbol(name),
+		{lex.Make(scanner.Int, strconv.Itoa(obj.NOSPLIT|obj.NOFRAME))},
+		{lex.Make('$', "$"), lex.Make(scanner.Int, "0")},
+	})
+	p.gnu.inText = true
+	if p.gnu.norvc {
+		p.gnuEmit("NORVC", "", nil)
+	}
+}
+
+// gnuIsFunction reports whether name is a function rather than a label.
+func (p *Parser) gnuIsFunction(name string) bool {
+	return p.gnu.globals[name] || p.gnu.functions[name]
+}
+
+// gnuSymbol returns the Go reference to the function or external symbol
+// name, as in ·name(SB).
+func (p *Parser) gnuSymbol(name string) []lex.Token {
+	var toks []lex.Token
+	switch {
+	case p.gnu.functions[name] && !p.gnu.globals[name]:
+		toks = []lex.Token{lex.Make(scanner.Ident, name), lex.Make('<', "<"), lex.Make('>', ">")}
+	case strings.Contains(name, ".") && !strings.HasPrefix(name, "."):
+		toks = []lex.Token{lex.Make(scanner.Ident, name)}
+	default:
+		toks = []lex.Token{lex.Make(scanner.Ident, "·"+name)}
+	}
+	return append(toks, lex.Make('(', "("), lex.Make(scanner.Ident, "SB"), lex.Make(')', ")"))
+}
+
+// gnuRegister returns the Go name of GNU register name.
+func (p *Parser) gnuRegister(name string) (string, bool) {
+	if name == "fp" {
+		return "S0", true
+	}
+	if name != strings.ToLower(name) {
+		return "", false
+	}
+	reg := strings.ToUpper(name)
+	switch reg {
+	case "SB", "FP", "PC", "TMP", "CTXT":
+		// Go names.
+		return "", false
+	}
+	if _, ok := p.arch.Register[reg]; !ok {
+		return "", false
+	}
+	return reg, true
+}
+
+// gnuExpr translates an expression, substituting .equ and .set symbols.
+func (p *Parser) gnuExpr(op []gnuToken) []lex.Token {
+	var toks []lex.Token
+	for _, tok := range op {
+		if val, ok := p.gnu.equates[tok.String()]; ok && tok.ScanToken == scanner.Ident {
+			if len(val) > 1 {
+				toks = append(toks, lex.Make('(', "("))
+				toks = append(toks, val...)
+				toks = append(toks, lex.Make(')', ")"))
+			} else {
+				toks = append(toks, val...)
+			}
+			continue
+		}
+		toks = append(toks, tok.Token)
+	}
+	return toks
+}
+
+// gnuMemory splits a memory operand off(reg) into off and the Go register.
+func (p *Parser) gnuMemory(op []gnuToken) ([]gnuToken, string, bool) {
+	n := len(op)
+	if n < 3 || op[n-3].ScanToken != '(' || op[n-2].ScanToken != scanner.Ident || op[n-1].ScanToken != ')' {
+		return nil, "", false
+	}
+	reg, ok := p.gnuRegister(op[n-2].String())
+	if !ok {
+		return nil, "", false
+	}
+	return op[:n-3], reg, true
+}
+
+// gnuOperand translates a register, memory or immediate operand.
+func (p *Parser) gnuOperand(op []gnuToken) []lex.Token {
+	if len(op) == 1 && op[0].ScanToken == scanner.Ident {
+		if reg, ok := p.gnuRegister(op[0].String()); ok {
+			return []lex.Token{lex.Make(scanner.Ident, reg)}
+		}
+	}
+	if off, reg, ok := p.gnuMemory(op); ok {
+		toks := p.gnuExpr(off)
+		return append(toks, lex.Make('(', "("), lex.Make(scanner.Ident, reg), lex.Make(')', ")"))
+	}
+	return append([]lex.Token{lex.Make('$', "$")}, p.gnuExpr(op)...)
+}
+
+// gnuTarget translates the target of a jump or branch: a label, or a
+// function or external symbol. If symbol is set, it is always a symbol.
+func (p *Parser) gnuTarget(op []gnuToken, symbol bool) []lex.Token {
+	if len(op) == 2 && op[0].ScanToken == scanner.Int && op[1].ScanToken == scanner.Ident && op[1].glued {
+		num, n := op[0].String(), p.gnu.numLabels[op[0].String()]
+		switch op[1].String() {
+		case "b":
+			if n == 0 {
+				p.errorf("undefined label %sb", num)
+			}
+			return []lex.Token{lex.Make(scanner.Ident, gnuNumLabel(num, n))}
+		case "f":
+			return []lex.Token{lex.Make(scanner.Ident, gnuNumLabel(num, n+1))}
+		}
+	}
+	name, n := gnuName(op)
+	if n == 0 {
+		p.errorf("expected label or symbol, found %s", gnuText(op))
+		return nil
+	}
+	if !symbol && !p.gnuIsFunction(name) && (!strings.Contains(name, ".") || strings.HasPrefix(name, ".")) {
+		return []lex.Token{lex.Make(scanner.Ident, name)}
+	}
+	toks := p.gnuSymbol(name)
+	if n < len(op) {
+		// Offset, as in sym+8(SB).
+		off := p.gnuExpr(op[n:])
+		toks = append(toks[:len(toks)-3:len(toks)-3], append(off, toks[len(toks)-3:]...)...)
+	}
+	return toks
+}
+
+// A gnuPseudoOp describes a GNU pseudo-instruction. Each arg is a Go
+// operand: the digit i stands for GNU operand i, Li for it as a label or
+// symbol, Si for it as a symbol, Ai for its address, and (i) for indirection
+// through it. Anything else is taken literally.
+type gnuPseudoOp struct {
+	as   string
+	n    int
+	args []string
+}
+
+var gnuPseudo = map[string]gnuPseudoOp{
+	"nop":    {"ADDI", 0, []string{"$0", "ZERO", "ZERO"}},
+	"li":     {"MOV", 2, []string{"1", "0"}},
+	"mv":     {"MOV", 2, []string{"1", "0"}},
+	"not":    {"XOR", 2, []string{"$-1", "1", "0"}},
+	"neg":    {"SUB", 2, []string{"1", "ZERO", "0"}},
+	"negw":   {"SUBW", 2, []string{"1", "ZERO", "0"}},
+	"sext.w": {"ADDIW", 2, []string{"$0", "1", "0"}},
+	"seqz":   {"SEQZ", 2, []string{"1", "0"}},
+	"snez":   {"SNEZ", 2, []string{"1", "0"}},
+	"sltz":   {"SLT", 2, []string{"ZERO", "1", "0"}},
+	"sgtz":   {"SLT", 2, []string{"1", "ZERO", "0"}},
+	"beqz":   {"BEQ", 2, []string{"0", "ZERO", "L1"}},
+	"bnez":   {"BNE", 2, []string{"0", "ZERO", "L1"}},
+	"blez":   {"BGE", 2, []string{"ZERO", "0", "L1"}},
+	"bgez":   {"BGE", 2, []string{"0", "ZERO", "L1"}},
+	"bltz":   {"BLT", 2, []string{"0", "ZERO", "L1"}},
+	"bgtz":   {"BLT", 2, []string{"ZERO", "0", "L1"}},
+	"bgt":    {"BLT", 3, []string{"1", "0", "L2"}},
+	"ble":    {"BGE", 3, []string{"1", "0", "L2"}},
+	"bgtu":   {"BLTU", 3, []string{"1", "0", "L2"}},
+	"bleu":   {"BGEU", 3, []string{"1", "0", "L2"}},
+	"j":      {"JMP", 1, []string{"L0"}},
+	"jr":     {"JMP", 1, []string{"(0)"}},
+	"ret":    {"RET", 0, nil},
+	"call":   {"CALL", 1, []string{"S0"}},
+	"tail":   {"JMP", 1, []string{"S0"}},
+	"la":     {"MOV", 2, []string{"A1", "0"}},
+	"lla":    {"MOV", 2, []string{"A1", "0"}},
+	"fmv.s":  {"MOVF", 2, []string{"1", "0"}},
+	"fmv.d":  {"MOVD", 2, []string{"1", "0"}},
+	"fneg.s": {"FNEGS", 2, []string{"1", "0"}},
+	"fneg.d": {"FNEGD", 2, []string{"1", "0"}},
+	"fabs.s": {"FSGNJXS", 2, []string{"1", "1", "0"}},
+	"fabs.d": {"FSGNJXD", 2, []string{"1", "1", "0"}},
+}
+
+// gnuLoads and gnuStores map loads and stores to the Go instructions that
+// take a memory operand.
+var gnuLoads = map[string]string{
+	"lb":      "MOVB",
+	"lbu":     "MOVBU",
+	"lh":      "MOVH",
+	"lhu":     "MOVHU",
+	"lw":      "MOVW",
+	"lwu":     "MOVWU",
+	"ld":      "MOV",
+	"flw":     "MOVF",
+	"fld":     "MOVD",
+	"c.lw":    "CLW",
+	"c.ld":    "CLD",
+	"c.fld":   "CFLD",
+	"c.lwsp":  "CLWSP",
+	"c.ldsp":  "CLDSP",
+	"c.fldsp": "CFLDSP",
+}
+
+var gnuStores = map[string]string{
+	"sb":      "MOVB",
+	"sh":      "MOVH",
+	"sw":      "MOVW",
+	"sd":      "MOV",
+	"fsw":     "MOVF",
+	"fsd":     "MOVD",
+	"c.sw":    "CSW",
+	"c.sd":    "CSD",
+	"c.fsd":   "CFSD",
+	"c.swsp":  "CSWSP",
+	"c.sdsp":  "CSDSP",
+	"c.fsdsp": "CFSDSP",
+}
+
+// gnuBranches lists the instructions whose operands are in the same order
+// in both syntaxes, the last being the target.
+var gnuBranches = map[string]bool{
+	"beq":    true,
+	"bne":    true,
+	"blt":    true,
+	"bge":    true,
+	"bltu":   true,
+	"bgeu":   true,
+	"c.beqz": true,
+	"c.bnez": true,
+	"c.j":    true,
+}
+
+// gnuInstruction translates and assembles a GNU instruction.
+func (p *Parser) gnuInstruction(name string, ops [][]gnuToken) {
+	if !p.gnu.inText {
+		p.errorf("%s: instruction outside of a function", name)
+		return
+	}
+	if p.gnu.align != 0 {
+		p.errorf("%s: alignment is only supported before a function", name)
+		p.gnu.align = 0
+	}
+	if p.gnuReloc(name, ops) {
+		return
+	}
+	p.gnuCheckHi()
+
+	if ps, ok := gnuPseudo[name]; ok {
+		if len(ops) != ps.n {
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		var args [][]lex.Token
+		for _, arg := range ps.args {
+			args = append(args, p.gnuPseudoArg(arg, ops))
+		}
+		p.gnuEmit(ps.as, "", args)
+		return
+	}
+
+	if strings.HasPrefix(name, "lr.") || strings.HasPrefix(name, "sc.") {
+		// LR and SC are always assembled with aq and rl set.
+		for _, order := range []string{".aqrl", ".aq", ".rl"} {
+			name = strings.TrimSuffix(name, order)
+		}
+	}
+	word := strings.ToUpper(strings.Replace(name, ".", "", -1))
+	cond := ""
+	if n := len(ops); n > 1 && len(ops[n-1]) == 1 {
+		rm := ops[n-1][0].String()
+		if _, ok := riscv.RoundingModes[strings.ToUpper(rm)]; ok && rm == strings.ToLower(rm) {
+			cond = "." + strings.ToUpper(rm)
+			ops = ops[:n-1]
+		}
+	}
+
+	var args [][]lex.Token
+	switch {
+	case gnuLoads[name] != "" || gnuStores[name] != "":
+		if len(ops) != 2 {
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		if _, _, ok := p.gnuMemory(ops[1]); !ok {
+			p.errorf("%s: expected memory operand, found %s", name, gnuText(ops[1]))
+			return
+		}
+		if word = gnuLoads[name]; word != "" {
+			args = [][]lex.Token{p.gnuOperand(ops[1]), p.gnuOperand(ops[0])}
+		} else {
+			word = gnuStores[name]
+			args = [][]lex.Token{p.gnuOperand(ops[0]), p.gnuOperand(ops[1])}
+		}
+
+	case gnuBranches[name]:
+		if len(ops) == 0 {
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		for _, op := range ops[:len(ops)-1] {
+			args = append(args, p.gnuOperand(op))
+		}
+		args = append(args, p.gnuTarget(ops[len(ops)-1], false))
+
+	case name == "sc.w" || name == "sc.d":
+		// sc.w rd, rs2, (rs1)
+		if len(ops) != 3 {
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		args = [][]lex.Token{p.gnuOperand(ops[1]), p.gnuOperand(ops[2]), p.gnuOperand(ops[0])}
+
+	case name == "fence" && len(ops) != 0:
+		// fence pred, succ, each a set of the letters iorw.
+		if len(ops) != 2 {
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		for _, op := range ops {
+			set, ok := gnuFenceSet(op)
+			if !ok {
+				p.errorf("%s: bad ordering set %s", name, gnuText(op))
+				return
+			}
+			args = append(args, []lex.Token{lex.Make('$', "$"), lex.Make(scanner.Int, strconv.Itoa(set))})
+		}
+
+	case name == "jal":
+		// jal [rd,] target
+		rd := []lex.Token{lex.Make(scanner.Ident, "RA")}
+		var target []lex.Token
+		switch len(ops) {
+		case 1:
+			target = p.gnuTarget(ops[0], false)
+		case 2:
+			rd = p.gnuOperand(ops[0])
+			target = p.gnuTarget(ops[1], false)
+		default:
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		if len(target) == 1 {
+			args = [][]lex.Token{rd, target}
+			break
+		}
+		// A symbol, which Go reaches with CALL or JMP.
+		switch rd[0].String() {
+		case "RA":
+			word = "CALL"
+		case "ZERO":
+			word = "JMP"
+		default:
+			p.errorf("%s: only ra and zero can be linked to a symbol", name)
+			return
+		}
+		args = [][]lex.Token{target}
+
+	case name == "jalr":
+		// jalr rs | jalr rd, rs | jalr rd, off(rs) | jalr rd, rs, off
+		rd := []lex.Token{lex.Make(scanner.Ident, "RA")}
+		var rs, off []gnuToken
+		switch len(ops) {
+		case 1:
+			rs = ops[0]
+		case 2:
+			rd = p.gnuOperand(ops[0])
+			rs = ops[1]
+		case 3:
+			rd = p.gnuOperand(ops[0])
+			rs, off = ops[1], ops[2]
+		default:
+			p.errorf("wrong number of arguments to %s instruction", name)
+			return
+		}
+		target := p.gnuOperand(rs)
+		if target[0].ScanToken == scanner.Ident {
+			// A register, not already off(reg).
+			target = append(p.gnuExpr(off), lex.Make('(', "("), target[0], lex.Make(')', ")"))
+		}
+		args = [][]lex.Token{rd, target}
+
+	default:
+		// Destination first becomes destination last.
+		for i := len(ops) - 1; i >= 0; i-- {
+			args = append(args, p.gnuOperand(ops[i]))
+		}
+	}
+	p.gnuEmit(word, cond, args)
+}
+
+// gnuFenceSet returns the 4-bit encoding of a fence ordering set
+// such as rw or iorw.
+func gnuFenceSet(op []gnuToken) (int, bool) {
+	if len(op) != 1 || op[0].ScanToken != scanner.Ident {
+		return 0, false
+	}
+	set := 0
+	for _, c := range op[0].String() {
+		i := strings.IndexRune("wroi", c)
+		if i < 0 || set&(1<<uint(i)) != 0 {
+			return 0, false
+		}
+		set |= 1 << uint(i)
+	}
+	return set, true
+}
+
+// gnuPseudoArg returns the Go operand for arg of a gnuPseudoOp.
+func (p *Parser) gnuPseudoArg(arg string, ops [][]gnuToken) []lex.Token {
+	i := strings.IndexAny(arg, "0123456789")
+	if i < 0 || arg[0] == '$' {
+		return lex.Tokenize(arg)
+	}
+	op := ops[arg[i]-'0']
+	switch arg[:i] {
+	case "":
+		return p.gnuOperand(op)
+	case "L":
+		return p.gnuTarget(op, false)
+	case "S":
+		return p.gnuTarget(op, true)
+	case "A":
+		return append([]lex.Token{lex.Make('$', "$")}, p.gnuTarget(op, true)...)
+	case "(":
+		reg := p.gnuOperand(op)
+		return []lex.Token{lex.Make('(', "("), reg[0], lex.Make(')', ")")}
+	}
+	panic("bad gnuPseudo argument " + arg)
+}
+
+// gnuEmit assembles a Go instruction.
+func (p *Parser) gnuEmit(word, cond string, operands [][]lex.Token) {
+	op, ok := p.arch.Instructions[word]
+	if !ok {
+		p.errorf("unrecognized instruction %q", strings.ToLower(word))
+		return
+	}
+	p.instruction(op, word, cond, operands)
+}
+
+// gnuReloc assembles an instruction using a relocation operator, reporting
+// whether it did.
+func (p *Parser) gnuReloc(name string, ops [][]gnuToken) bool {
+	// Find the operator, as in %pcrel_lo(1b)(a0).
+	i, n := -1, len(ops)
+	for j, op := range ops {
+		if len(op) > 0 && op[0].ScanToken == '%' {
+			i = j
+		}
+	}
+	if i < 0 {
+		return false
+	}
+	op := ops[i]
+	if len(op) < 5 || op[1].ScanToken != scanner.Ident || op[2].ScanToken != '(' {
+		p.errorf("%s: malformed relocation operator %s", name, gnuText(op))
+		return true
+	}
+	reloc := op[1].String()
+	end := 3
+	for nesting := 1; end < len(op) && nesting > 0; end++ {
+		switch op[end].ScanToken {
+		case '(':
+			nesting++
+		case ')':
+			nesting--
+		}
+	}
+	arg, rest := op[3:end-1], op[end:]
+
+	switch reloc {
+	case "hi", "pcrel_hi":
+		if name != map[string]string{"hi": "lui", "pcrel_hi": "auipc"}[reloc] || n != 2 || i != 1 || len(rest) != 0 {
+			p.errorf("%s: unsupported use of %%%s", name, reloc)
+			return true
+		}
+		p.gnuCheckHi()
+		sym := p.address(p.gnuTarget(arg, true))
+		prog := &obj.Prog{
+			Ctxt:  p.ctxt,
+			Pos:   p.pos(),
+			As:    riscv.AAUIPC,
+			From:  obj.Addr{Type: obj.TYPE_ADDR, Name: sym.Name, Offset: sym.Offset, Sym: sym.Sym},
+			From3: &obj.Addr{},
+			To:    p.gnuRegOperand(name, ops[0]),
+		}
+		p.append(prog, "", true)
+		p.gnu.hi, p.gnu.hiOp = prog, reloc
+		return true
+
+	case "lo", "pcrel_lo":
+		hi := p.gnu.hi
+		p.gnu.hi = nil
+		want := map[string]string{"lo": "hi", "pcrel_lo": "pcrel_hi"}[reloc]
+		if hi == nil || hi != p.lastProg || p.gnu.hiOp != want {
+			p.errorf("%s: %%%s must immediately follow its %%%s", name, reloc, want)
+			return true
+		}
+		if reloc == "lo" {
+			sym := p.address(p.gnuTarget(arg, true))
+			if sym.Sym != hi.From.Sym || sym.Offset != hi.From.Offset {
+				p.errorf("%s: %%lo(%s) does not match the preceding %%hi", name, gnuText(arg))
+				return true
+			}
+		} else {
+			label := p.gnuTarget(arg, false)
+			if len(label) != 1 || p.labels[label[0].String()] != hi {
+				p.errorf("%s: %%pcrel_lo(%s) does not refer to the preceding auipc", name, gnuText(arg))
+				return true
+			}
+		}
+
+		prog := &obj.Prog{
+			Ctxt:  p.ctxt,
+			Pos:   p.pos(),
+			From:  obj.Addr{Type: obj.TYPE_CONST},
+			From3: &obj.Addr{},
+			Mark:  riscv.NOCOMPRESS,
+		}
+		var base obj.Addr
+		switch {
+		case name == "addi" && n == 3 && i == 2 && len(rest) == 0:
+			// addi rd, rs, %lo(sym)
+			prog.As = riscv.AADDI
+			base = p.gnuRegOperand(name, ops[1])
+			*prog.From3 = base
+			prog.To = p.gnuRegOperand(name, ops[0])
+			hi.Mark |= riscv.NEED_PCREL_ITYPE_RELOC | riscv.NOCOMPRESS
+		case gnuLoads[name] != "" && !strings.HasPrefix(name, "c.") && n == 2 && i == 1:
+			// ld rd, %lo(sym)(rs)
+			prog.As = p.arch.Instructions[strings.ToUpper(name)]
+			base = p.gnuRegOperand(name, gnuParens(rest))
+			*prog.From3 = base
+			prog.To = p.gnuRegOperand(name, ops[0])
+			hi.Mark |= riscv.NEED_PCREL_ITYPE_RELOC | riscv.NOCOMPRESS
+		case gnuStores[name] != "" && !strings.HasPrefix(name, "c.") && n == 2 && i == 1:
+			// sd rs2, %lo(sym)(rs1)
+			prog.As = p.arch.Instructions[strings.ToUpper(name)]
+			base = p.gnuRegOperand(name, gnuParens(rest))
+			*prog.From3 = p.gnuRegOperand(name, ops[0])
+			prog.To = base
+			hi.Mark |= riscv.NEED_PCREL_STYPE_RELOC | riscv.NOCOMPRESS
+		default:
+			p.errorf("%s: unsupported use of %%%s", name, reloc)
+			return true
+		}
+		if base.Reg != hi.To.Reg {
+			p.errorf("%s: %%%s must use the register set by its %%%s", name, reloc, want)
+			return true
+		}
+		p.append(prog, "", true)
+		return true
+	}
+	p.errorf("%s: unsupported relocation operator %%%s", name, reloc)
+	return true
+}
+
+// gnuParens returns the contents of a parenthesized operand.
+func gnuParens(op []gnuToken) []gnuToken {
+	if len(op) < 2 || op[0].ScanToken != '(' || op[len(op)-1].ScanToken != ')' {
+		return nil
+	}
+	return op[1 : len(op)-1]
+}
+
+// gnuRegOperand returns the register operand op.
+func (p *Parser) gnuRegOperand(name string, op []gnuToken) obj.Addr {
+	if len(op) == 1 {
+		if reg, ok := p.gnuRegister(op[0].String()); ok {
+			return obj.Addr{Type: obj.TYPE_REG, Reg: p.arch.Register[reg]}
+		}
+	}
+	p.errorf("%s: expected register, found %s", name, gnuText(op))
+	return obj.Addr{}
+}
+
+// gnuCheckHi diagnoses a %hi or %pcrel_hi whose %lo or %pcrel_lo was not
+// the next instruction.
+func (p *Parser) gnuCheckHi() {
+	if p.gnu.hi != nil {
+		p.errorf("%%%s is not followed by its %%%s", p.gnu.hiOp, strings.Replace(p.gnu.hiOp, "hi", "lo", 1))
+		p.gnu.hi = nil
+	}
+}
+
+// gnuDirective processes a directive. It returns false at .end.
+func (p *Parser) gnuDirective(name string, ops [][]gnuToken) bool {
+	switch name {
+	case ".text":
+		// Nothing to do.
+	case ".section":
+		if len(ops) == 0 || !strings.HasPrefix(gnuText(ops[0]), ".text") {
+			p.errorf("%s: only text sections are supported", name)
+		}
+	case ".data", ".bss", ".rodata":
+		p.errorf("%s: only text sections are supported", name)
+	case ".globl", ".global":
+		for _, op := range ops {
+			p.gnu.globals[gnuText(op)] = true
+		}
+	case ".type":
+		if len(ops) == 2 && strings.TrimLeft(gnuText(ops[1]), "@%") == "function" {
+			p.gnu.functions[gnuText(ops[0])] = true
+		}
+	case ".equ", ".set":
+		if len(ops) != 2 {
+			p.errorf("expect two operands for %s", name)
+			break
+		}
+		p.gnu.equates[gnuText(ops[0])] = p.gnuExpr(ops[1])
+	case ".option":
+		if len(ops) != 1 {
+			p.errorf("expect one operand for %s", name)
+			break
+		}
+		norvc := p.gnu.norvc
+		switch opt := gnuText(ops[0]); opt {
+		case "rvc":
+			norvc = false
+		case "norvc":
+			norvc = true
+		case "push":
+			p.gnu.options = append(p.gnu.options, norvc)
+		case "pop":
+			if len(p.gnu.options) == 0 {
+				p.errorf(".option pop without .option push")
+				break
+			}
+			norvc = p.gnu.options[len(p.gnu.options)-1]
+			p.gnu.options = p.gnu.options[:len(p.gnu.options)-1]
+		case "pic", "nopic", "relax", "norelax":
+			// Nothing to do.
+		default:
+			p.errorf("unsupported option %s", opt)
+		}
+		if norvc != p.gnu.norvc && p.gnu.inText {
+			p.gnuCheckHi()
+			if norvc {
+				p.gnuEmit("NORVC", "", nil)
+			} else {
+				p.gnuEmit("RVC", "", nil)
+			}
+		}
+		p.gnu.norvc = norvc
+	case ".align", ".p2align", ".balign":
+		if len(ops) == 0 {
+			p.errorf("expect operand for %s", name)
+			break
+		}
+		align := p.evalInteger(name, p.gnuExpr(ops[0]))
+		if name != ".balign" {
+			align = 1 << uint(align)
+		}
+		p.gnu.align = align
+	case ".word", ".4byte", ".long", ".dword", ".8byte", ".quad":
+		if !p.gnu.inText {
+			p.errorf("%s: data outside of a function", name)
+			break
+		}
+		p.gnuCheckHi()
+		for _, op := range ops {
+			val := p.gnuExpr(op)
+			switch name {
+			case ".dword", ".8byte", ".quad":
+				v := p.evalInteger(name, val)
+				p.gnuEmit("WORD", "", [][]lex.Token{lex.Tokenize(fmt.Sprintf("$%d", uint32(v)))})
+				p.gnuEmit("WORD", "", [][]lex.Token{lex.Tokenize(fmt.Sprintf("$%d", uint32(v>>32)))})
+			default:
+				p.gnuEmit("WORD", "", [][]lex.Token{append([]lex.Token{lex.Make('$', "$")}, val...)})
+			}
+		}
+	case ".size", ".file", ".ident", ".attribute", ".addrsig":
+		// Nothing to do.
+	case ".end":
+		p.gnuCheckHi()
+		return false
+	default:
+		if !strings.HasPrefix(name, ".cfi_") {
+			p.errorf("unsupported directive %s", name)
+		}
+	}
+	return true
+}
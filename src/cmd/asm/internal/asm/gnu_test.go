@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package asm
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"cmd/asm/internal/flags"
+	"cmd/asm/internal/lex"
+	"cmd/internal/obj"
+)
+
+// assemble assembles file and returns its code, one line per function
+// with its relocations.
+func assemble(t *testing.T, goarch, file string, gnu bool) []string {
+	defer func(old bool) { *flags.GNU = old }(*flags.GNU)
+	*flags.GNU = gnu
+
+	input := filepath.Join("testdata", file+".s")
+	architecture, ctxt := setArch(goarch)
+	lexer := lex.NewLexer(input)
+	parser := NewParser(ctxt, architecture, lexer)
+	pList := obj.Linknewplist(ctxt)
+	var ok bool
+	testOut = nil
+	failed := false
+	ctxt.DiagFunc = func(format string, args ...interface{}) {
+		failed = true
+		t.Errorf(format, args...)
+	}
+	pList.Firstpc, ok = parser.Parse()
+	if !ok || failed {
+		t.Fatalf("asm: %s assembly failed", file)
+	}
+	obj.FlushplistNoFree(ctxt)
+
+	var code []string
+	for _, s := range ctxt.Text {
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "%s: %x", s.Name, s.P)
+		for _, r := range s.R {
+			fmt.Fprintf(&buf, " %d:%d:%d:%s+%d", r.Off, r.Siz, r.Type, r.Sym.Name, r.Add)
+		}
+		code = append(code, buf.String())
+	}
+	return code
+}
+
+func TestRISCVGNU(t *testing.T) {
+	defer setRISCVRVC(true)()
+	have := assemble(t, "riscv", "riscvgnu", true)
+	want := assemble(t, "riscv", "riscvgnugo", false)
+	if len(have) != len(want) {
+		t.Fatalf("have %d functions, want %d", len(have), len(want))
+	}
+	for i := range have {
+		if have[i] != want[i] {
+			t.Errorf("have %s\nwant %s", have[i], want[i])
+		}
+	}
+
+	assemble(t, "riscv", "riscv-gas", true)
+}
+
+func TestRISCVGNUErrors(t *testing.T) {
+	defer func(old bool) { *flags.GNU = old }(*flags.GNU)
+	defer func(old bool) { *flags.AllErrors = old }(*flags.AllErrors)
+	*flags.GNU = true
+	*flags.AllErrors = true
+	testErrors(t, "riscv", "riscvgnuerror")
+}
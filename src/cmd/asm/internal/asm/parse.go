@@ -42,6 +42,7 @@ type Parser struct {
 	dataAddr      map[string]int64 // Most recent address for DATA for this symbol.
 	isJump        bool             // Instruction being assembled is a jump.
 	errorWriter   io.Writer
+	gnu           *gnuState // GNU syntax state; nil unless -gnu.
 }
 
 type Patch struct {
@@ -50,7 +51,7 @@ type Patch struct {
 }
 
 func NewParser(ctxt *obj.Link, ar *arch.Arch, lexer lex.TokenReader) *Parser {
-	return &Parser{
+	p := &Parser{
 		ctxt:        ctxt,
 		arch:        ar,
 		lex:         lexer,
@@ -58,6 +59,10 @@ func NewParser(ctxt *obj.Link, ar *arch.Arch, lexer lex.TokenReader) *Parser {
 		dataAddr:    make(map[string]int64),
 		errorWriter: os.Stderr,
 	}
+	if *flags.GNU {
+		p.gnu = newGNUState()
+	}
+	return p
 }
 
 // panicOnError is enabled when testing to abort execution on the first error
@@ -101,6 +106,9 @@ func (p *Parser) Parse() (*obj.Prog, bool) {
 
 // WORD [ arg {, arg} ] (';' | '\n')
 func (p *Parser) line() bool {
+	if p.gnu != nil {
+		return p.gnuLine()
+	}
 	// Skip newlines.
 	var tok lex.ScanToken
 	for {
@@ -134,12 +142,12 @@ func (p *Parser) line() bool {
 		for {
 			tok = p.lex.Next()
 			if len(operands) == 0 && len(items) == 0 {
-				if p.arch.InFamily(sys.ARM, sys.ARM64) && tok == '.' {
-					// ARM conditionals.
+				if p.arch.InFamily(sys.ARM, sys.ARM64, sys.RISCV) && tok == '.' {
+					// ARM conditionals and RISC-V rounding modes.
 					tok = p.lex.Next()
 					str := p.lex.Text()
 					if tok != scanner.Ident {
-						p.errorf("ARM condition expected identifier, found %s", str)
+						p.errorf("instruction suffix expected identifier, found %s", str)
 					}
 					cond = cond + "." + str
 					continue
@@ -187,6 +195,10 @@ func (p *Parser) line() bool {
 			p.errorf("missing operand")
 		}
 	}
+	if p.arch.Family == sys.RISCV {
+		// Explicitly compressed instructions: C.ADDI is CADDI.
+		word, cond = arch.RISCVCompressed(word, cond)
+	}
 	if p.pseudo(word, operands) {
 		return true
 	}
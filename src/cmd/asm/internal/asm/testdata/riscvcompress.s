@@ -0,0 +1,66 @@
+TEXT asmtest(SB),7,$0
+start:
+	// Explicitly compressed instructions.
+	C.LWSP	8(SP), A0		// CLWSP 8(SP), A0		// 2245
+	C.LDSP	8(SP), A0		// CLDSP 8(SP), A0		// 2265
+	C.FLDSP	8(SP), FA0		// CFLDSP 8(SP), FA0		// 2225
+	C.SWSP	A0, 8(SP)		// CSWSP A0, 8(SP)		// 2ac4
+	C.SDSP	A0, 8(SP)		// CSDSP A0, 8(SP)		// 2ae4
+	C.FSDSP	FA0, 8(SP)		// CFSDSP FA0, 8(SP)		// 2aa4
+	C.LW	4(A1), A0		// CLW 4(A1), A0		// c841
+	C.LD	8(A1), A0		// CLD 8(A1), A0		// 8865
+	C.FLD	8(A1), FA0		// CFLD 8(A1), FA0		// 8825
+	C.SW	A0, 4(A1)		// CSW A0, 4(A1)		// c8c1
+	C.SD	A0, 8(A1)		// CSD A0, 8(A1)		// 88e5
+	C.FSD	FA0, 8(A1)		// CFSD FA0, 8(A1)		// 88a5
+
+	C.J	start			// CJ 2				// e5b7
+	C.BEQZ	A0, start		// CBEQZ A0, 2			// 7dd1
+	C.BNEZ	A0, start		// CBNEZ A0, 2			// 75f1
+	C.JR	T0			// CJR T0			// 8282
+	C.JALR	T0			// CJALR T0			// 8292
+
+	C.LI	$-1, T0			// CLI $-1, T0			// fd52
+	C.LUI	$1, T0			// CLUI $1, T0			// 8562
+	C.ADDI	$1, T0			// CADDI $1, T0			// 8502
+	C.ADDIW	$-1, T0			// CADDIW $-1, T0		// fd32
+	C.ADDI16SP	$-64, SP	// CADDI16SP $-64, SP		// 3971
+	C.ADDI4SPN	$16, A0		// CADDI4SPN $16, A0		// 0808
+	C.SLLI	$3, T0			// CSLLI $3, T0			// 8e02
+	C.SRLI	$3, A0			// CSRLI $3, A0			// 0d81
+	C.SRAI	$3, A0			// CSRAI $3, A0			// 0d85
+	C.ANDI	$-2, A0			// CANDI $-2, A0		// 7999
+	C.MV	T1, T0			// CMV T1, T0			// 9a82
+	C.ADD	T1, T0			// CADD T1, T0			// 9a92
+	C.AND	A1, A0			// CAND A1, A0			// 6d8d
+	C.OR	A1, A0			// COR A1, A0			// 4d8d
+	C.XOR	A1, A0			// CXOR A1, A0			// 2d8d
+	C.SUB	A1, A0			// CSUB A1, A0			// 0d8d
+	C.ADDW	A1, A0			// CADDW A1, A0			// 2d9d
+	C.SUBW	A1, A0			// CSUBW A1, A0			// 0d9d
+	C.NOP				// CNOP				// 0100
+	C.EBREAK			// CEBREAK			// 0290
+
+	// Automatically compressed instructions.
+	ADD	$1, T0			// 8502
+	ADDIW	$-1, T0, T0		// fd32
+	MOV	$-1, T0			// fd52
+	MOV	T1, T0			// 9a82
+	SLL	$3, T0			// 8e02
+	ADD	$-64, SP		// 3971
+	ADD	$16, SP, A0		// 0808
+
+	// Not compressible.
+	MOV	$32, T0			// 93020002
+	ADD	$64, T0			// 93820204
+	ADD	$1, T0, T1		// 13831200
+	SLL	$3, T0, T1		// 13933200
+
+	// Automatic compression is off between NORVC and RVC; explicitly
+	// compressed instructions are unaffected.
+	NORVC
+	ADD	$1, T0			// 93821200
+	MOV	T1, T0			// 93020300
+	C.ADDI	$1, T0			// CADDI $1, T0			// 8502
+	RVC
+	ADD	$1, T0			// 8502
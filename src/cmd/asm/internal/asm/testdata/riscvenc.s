@@ -1,5 +1,6 @@
 TEXT asmtest(SB),7,$0
 start:
+	NORVC
 	ADD	T1, T0, T2			// b3836200
 	ADD	T0, T1				// 33035300
 	ADD	$2047, T0, T1			// 1383f27f
@@ -122,6 +123,71 @@ start:
 	REMW	T0, T1, T2			// bb635302
 	REMUW	T0, T1, T2			// bb735302
 
+	// A extension
+	LRW	(A0), A3			// af260516
+	SCW	A2, (A0), A4			// 2f27c51e
+	LRD	(A0), A3			// af360516
+	SCD	A2, (A0), A4			// 2f37c51e
+
+	// Memory ordering
+	FENCE					// 0f00f00f
+	FENCE	$3, $1				// 0f001003
+	FENCETSO				// 0f003083
+	FENCEI					// 0f100000
+	PAUSE					// 0f000001
+
+	// Privileged instructions
+	MRET					// 73002030
+	SRET					// 73002010
+	WFI					// 73005010
+	SFENCEVMA				// 73000012
+	SFENCEVMA	A0			// 73000512
+	SFENCEVMA	A1, A0			// 7300b512
+	HFENCEVVMA	A1, A0			// 7300b522
+	HFENCEGVMA	A1, A0			// 7300b562
+
+	// Cache-block operations
+	CBOCLEAN	(A0)			// 0f201500
+	CBOFLUSH	(A0)			// 0f202500
+	CBOINVAL	(A0)			// 0f200500
+	CBOZERO	(A0)				// 0f204500
+
+	// Bit-manipulation
+	ANDN	T1, T0, T2			// b3f36240
+	ORN	T1, T0, T2			// b3e36240
+	XNOR	T1, T0, T2			// b3c36240
+	ROL	T1, T0, T2			// b3936260
+	ROR	T1, T0, T2			// b3d36260
+	ROLW	T1, T0, T2			// bb936260
+	RORW	T1, T0, T2			// bbd36260
+	ROR	$3, T0, T1			// 13d33260
+	RORI	$63, T0, T1			// 13d3f263
+	RORW	$3, T0, T1			// 1bd33260
+	RORIW	$31, T0, T1			// 1bd3f261
+	REV8	T0, T1				// 13d3826b
+
+	// Carry-less multiplication
+	CLMUL	T1, T0, T2			// b393620a
+	CLMULH	T1, T0, T2			// b3b3620a
+	CLMULR	T1, T0, T2			// b3a3620a
+	CLMUL	T1, T2				// b393630a
+
+	// Scalar cryptography
+	AES64DS	T1, T0, T2			// b383623a
+	AES64DSM	T1, T0, T2		// b383623e
+	AES64ES	T1, T0, T2			// b3836232
+	AES64ESM	T1, T0, T2		// b3836236
+	AES64IM	T0, T1				// 13930230
+	AES64KS1I	$10, T0, T1		// 1393a231
+	AES64KS2	T1, T0, T2		// b383627e
+	SHA256SIG0	T0, T1			// 13932210
+	SHA256SIG1	T0, T1			// 13933210
+	SHA256SUM0	T0, T1			// 13930210
+	SHA256SUM1	T0, T1			// 13931210
+	SHA512SIG0	T0, T1			// 13936210
+	SHA512SIG1	T0, T1			// 13937210
+	SHA512SUM0	T0, T1			// 13934210
+	SHA512SUM1	T0, T1			// 13935210
 
 	// F extension
 	FADDS	FT1, FT0, FT2			// 53011000
@@ -133,10 +199,16 @@ start:
 	FSGNJS	FT1, FT0, FT2			// 53011020
 	FSGNJNS	FT1, FT0, FT2			// 53111020
 	FSGNJXS	FT1, FT0, FT2			// 53211020
+	FMINS	FT1, FT0, FT2			// 53011028
+	FMAXS	FT1, FT0, FT2			// 53111028
 	FCVTSW	T0, FT0				// 538002d0
 	FCVTSL	T0, FT0				// 538022d0
-	FCVTWS	FT0, T0				// d30200c0
-	FCVTLS	FT0, T0				// d30220c0
+	FCVTWS	FT0, T0				// d31200c0
+	FCVTLS	FT0, T0				// d31220c0
+	FCVTWS.RTZ	FT0, T0			// d31200c0
+	FCVTLS.RTZ	FT0, T0			// d31220c0
+	FCVTLUS.RUP	FT0, T0			// d33230c0
+	FADDS.DYN	FT1, FT0, FT2		// 53711000
 	MOVF	4(T0), FT0			// 07a04200
 	MOVF	FT0, 4(T0)			// 27a20200
 	MOVF	FT0, FT1			// d3000020
@@ -144,6 +216,7 @@ start:
 	FNES	FT0, FT1, T2			// d3a300a0
 	FLTS	FT0, FT1, T2			// d39300a0
 	FLES	FT0, FT1, T2			// d38300a0
+	FCLASSS	FT0, T0				// d31200e0
 
 
 	// D extension
@@ -156,10 +229,18 @@ start:
 	FSGNJD	FT1, FT0, FT2			// 53011022
 	FSGNJND	FT1, FT0, FT2			// 53111022
 	FSGNJXD	FT1, FT0, FT2			// 53211022
+	FMIND	FT1, FT0, FT2			// 5301102a
+	FMAXD	FT1, FT0, FT2			// 5311102a
 	FCVTDW	T0, FT0				// 538002d2
 	FCVTDL	T0, FT0				// 538022d2
-	FCVTWD	FT0, T0				// d30200c2
-	FCVTLD	FT0, T0				// d30220c2
+	FCVTWD	FT0, T0				// d31200c2
+	FCVTLD	FT0, T0				// d31220c2
+	FCVTWD.RTZ	FT0, T0			// d31200c2
+	FCVTLD.RTZ	FT0, T0			// d31220c2
+	FCVTLD.RNE	FT0, T0			// d30220c2
+	FCVTLUD.RUP	FT0, T0			// d33230c2
+	FCVTSD.RMM	FT0, FT1		// d3401040
+	FADDD.RDN	FT1, FT0, FT2		// 53211002
 	MOVD	4(T0), FT0			// 07b04200
 	MOVD	FT0, 4(T0)			// 27b20200
 	MOVD	FT0, FT1			// d3000022
@@ -167,3 +248,4 @@ start:
 	FNED	FT0, FT1, T0			// d3a200a2
 	FLTD	FT0, FT1, T0			// d39200a2
 	FLED	FT0, FT1, T0			// d38200a2
+	FCLASSD	FT0, T0				// d31200e2
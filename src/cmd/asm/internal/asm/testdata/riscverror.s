@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+TEXT errors(SB),7,$0
+	C.ADDI	$0, T0			// ERROR "cannot be encoded in a compressed instruction"
+	C.ADDI	$32, T0			// ERROR "cannot be encoded in a compressed instruction"
+	C.LI	$1, ZERO		// ERROR "cannot be encoded in a compressed instruction"
+	C.LUI	$0, T0			// ERROR "cannot be encoded in a compressed instruction"
+	C.LW	4(T0), A0		// ERROR "cannot be encoded in a compressed instruction"
+	C.LD	4(A1), A0		// ERROR "cannot be encoded in a compressed instruction"
+	C.SDSP	A0, 512(SP)		// ERROR "cannot be encoded in a compressed instruction"
+	C.AND	T1, T0			// ERROR "cannot be encoded in a compressed instruction"
+	C.ADDI16SP	$8, SP		// ERROR "cannot be encoded in a compressed instruction"
+	C.ADDI	$4096, T0		// ERROR "cannot be larger than 12 bits"
+	RET
+
+TEXT lrsc(SB),7,$0
+	LRW	(A0), A1
+	MOVW	(A2), A3		// ERROR "instruction not allowed in LR/SC sequence"
+	MUL	A1, A1, A1		// ERROR "instruction not allowed in LR/SC sequence"
+	MOV	$0x123456789a, T0	// ERROR "constant pool load not allowed in LR/SC sequence"
+	CALL	errors(SB)		// ERROR "instruction not allowed in LR/SC sequence"
+loop:
+	ADD	$1, A1
+	BNE	A1, ZERO, loop		// ERROR "backward branch not allowed in LR/SC sequence"
+	BNE	A1, ZERO, done
+	SCW	A1, (A0), A2
+	LRW	(A0), A1
+	LRD	(A0), A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	ADD	$1, A1
+	SCD	A1, (A0), A2		// ERROR "LR/SC sequence is longer than 16 instructions"
+	LRW	4(A0), A1		// ERROR "LR needs a register-indirect address"
+done:
+	RET
+
+TEXT privileged(SB),7,$0
+	FENCE	$16, $1			// ERROR "FENCE sets must be in the range [0, 15]"
+	FENCE	$1, A0			// ERROR "FENCE needs a predecessor and successor set"
+	CBOZERO	8(A0)			// ERROR "cache-block operation needs a register-indirect address"
+	CBOCLEAN	A0		// ERROR "cache-block operation needs a register-indirect address"
+	RET
+
+TEXT bitmanip(SB),7,$0
+	ROR	$64, T0, T1		// ERROR "must be in the range [0, 63]"
+	RORW	$32, T0, T1		// ERROR "must be in the range [0, 31]"
+	AES64KS1I	$11, T0, T1	// ERROR "must be in the range [0, 10]"
+	RET
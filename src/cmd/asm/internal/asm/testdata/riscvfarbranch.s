@@ -1,6 +1,8 @@
 TEXT asmtest(SB),7,$0
-	// Test far branch handling.
+	// Test far branch handling.  Compression would bring the branch
+	// target within range.
 farbranch:
+	NORVC
 	ADD	$0, ZERO, ZERO
 	ADD	$0, ZERO, ZERO
 	ADD	$0, ZERO, ZERO
@@ -0,0 +1,182 @@
+# Copyright 2017 The Go Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+# GNU syntax input for TestRISCVGNU, which checks that it assembles
+# to the same code as riscvgnugo.s.
+
+#define SIZE 16
+
+	.text
+	.option	norelax
+	.equ	STEP, 8
+	.set	TWOSTEPS, STEP*2
+
+	.globl	copy
+	.type	copy, @function
+	.p2align 3
+copy:
+	ld	t0, (sp)
+	ld	t1, STEP(sp)
+	ld	t2, TWOSTEPS(sp)
+1:	bge	zero, t2, 2f
+	lbu	t4, 0(t1)
+	sb	t4, 0(t0)
+	addi	t0, t0, 1; addi t1, t1, 1
+	addi	t2, t2, -1
+	j	1b
+2:	ret
+	.size	copy, .-copy
+
+	.type	helper, @function
+helper:
+	.cfi_startproc
+	nop
+	li	a0, SIZE
+	li	a1, 0x12345
+	mv	a2, a0
+	not	a3, a2
+	neg	a4, a3
+	negw	a5, a4
+	sext.w	a6, a5
+	seqz	a7, a6
+	snez	s2, a7
+	sltz	s3, s2
+	sgtz	s4, s3
+	beqz	a0, .Lend
+	bnez	a0, .Lend
+	blez	a0, .Lend
+	bgez	a0, .Lend
+	bltz	a0, .Lend
+	bgtz	a0, .Lend
+	bgt	a0, a1, .Lend
+	ble	a0, a1, .Lend
+	bgtu	a0, a1, .Lend
+	bleu	a0, a1, .Lend
+	beq	a0, a1, .Lend
+	bltu	a0, a1, .Lend
+	add	a0, a1, a2
+	sub	a0, a1, a2
+	subw	a0, a1, a2
+	sll	a0, a1, a2
+	slli	a0, a1, 3
+	srai	a0, a1, 3
+	andi	a0, a1, 0xff
+	mul	a0, a1, a2
+	divu	a0, a1, a2
+	remw	a0, a1, a2
+	lui	a0, 0x12
+	auipc	a0, 0x12
+	lw	a0, -4(fp)
+	lwu	a1, 4(s0)
+	lh	a2, 2(sp)
+	lhu	a3, 2(sp)
+	lb	a4, 1(sp)
+	sw	a0, -4(fp)
+	sh	a2, 2(sp)
+	jalr	a0
+	jalr	ra, a0
+	jalr	ra, 8(a0)
+	jalr	t0, a0, 16
+	jr	t0
+	call	copy
+	call	runtime.memmove
+	jal	copy
+	jal	zero, copy
+	jal	t0, .Lend
+	tail	copy
+.Lend:
+	ret
+	.cfi_endproc
+
+	.type	float, @function
+float:
+	flw	ft0, 0(a0)
+	fld	ft1, 8(a0)
+	fsw	ft0, 0(a1)
+	fsd	ft1, 8(a1)
+	fadd.s	ft2, ft0, ft1
+	fadd.d	ft2, ft0, ft1, rtz
+	fmul.d	fa0, fa1, fa2
+	fmv.s	fa0, fa1
+	fmv.d	fa0, fa1
+	fneg.s	fa0, fa1
+	fneg.d	fa0, fa1
+	fabs.s	fa0, fa1
+	fabs.d	fa0, fa1
+	fcvt.w.s	a0, ft0, rtz
+	fcvt.d.l	ft0, a0
+	fcvt.l.d	a0, ft0, rne
+	feq.d	a0, ft0, ft1
+	ret
+
+	.globl	reloc
+	.type	reloc, @function
+	.balign	8
+reloc:
+.Lpc0:	auipc	a0, %pcrel_hi(runtime.memmove)
+	addi	a0, a0, %pcrel_lo(.Lpc0)
+1:	auipc	a1, %pcrel_hi(copy+8)
+	ld	a1, %pcrel_lo(1b)(a1)
+	lui	t6, %hi(table)
+	sd	a3, %lo(table)(t6)
+	lui	a4, %hi(table+16)
+	addi	a4, a4, %lo(table+16)
+	la	a5, copy
+	lla	a6, runtime.memmove
+	ret
+
+	.type	compress, @function
+compress:
+	add	a0, a0, a1
+	.option	push
+	.option	norvc
+	add	a0, a0, a1
+	.option	pop
+	add	a0, a0, a1
+	c.add	a0, a1
+	c.li	a0, 5
+	c.lw	a0, 4(a1)
+	c.sw	a0, 4(a1)
+	c.ldsp	a0, 8(sp)
+	c.sdsp	a0, 8(sp)
+	c.beqz	a0, 1f
+	c.j	1f
+1:	c.jr	ra
+
+	.type	cas, @function
+cas:
+1:	lr.w.aqrl	a3, (a0)
+	bne	a3, a1, 2f
+	sc.w.rl	a4, a2, (a0)
+	bnez	a4, 1b
+2:	lr.d	a3, (a0)
+	sc.d	a4, a2, (a0)
+	ret
+
+	.type	priv, @function
+priv:
+	fence
+	fence	rw, w
+	fence	iorw, iorw
+	fence.tso
+	fence.i
+	pause
+	sfence.vma
+	sfence.vma	a0
+	sfence.vma	a0, a1
+	hfence.gvma	a0, a1
+	cbo.zero	(a0)
+	cbo.flush	0(a1)
+	wfi
+	mret
+
+	.globl	data
+	.type	data, @function
+data:
+	.word	0x12345678, 7
+	.4byte	1
+	.dword	0x123456789abcdef0
+	.end
+
+	this is ignored
@@ -0,0 +1,33 @@
+# Copyright 2017 The Go Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+	nop					// ERROR "outside of a function"
+	.data					// ERROR "only text sections are supported"
+	.text
+	.globl	f
+	.balign	16
+f:						// ERROR "alignment 16 is larger than 8"
+	frob	a0				// ERROR "unrecognized instruction"
+	lw	a0, a1				// ERROR "expected memory operand"
+	j	3b				// ERROR "undefined label 3b"
+	.foo					// ERROR "unsupported directive .foo"
+	.option	arch, +c			// ERROR "expect one operand for .option"
+	.option	arch				// ERROR "unsupported option arch"
+	.option	pop				// ERROR ".option pop without .option push"
+	.p2align 2
+	nop					// ERROR "alignment is only supported before a function"
+	addi	a0, a0, %got(x)			// ERROR "unsupported relocation operator %got"
+	addi	a0, a0, %lo(x)			// ERROR "%lo must immediately follow its %hi"
+	auipc	a0, %pcrel_hi(x)
+	nop					// ERROR "%pcrel_hi is not followed by its %pcrel_lo"
+	lui	a0, %hi(x)
+	addi	a1, a1, %lo(x)			// ERROR "%lo must use the register set by its %hi"
+	lui	a0, %hi(x)
+	addi	a0, a0, %lo(y)			// ERROR "does not match"
+	auipc	a0, %pcrel_hi(x)
+	addi	a0, a0, %lo(x)			// ERROR "%lo must immediately follow its %hi"
+	lui	a0, %pcrel_hi(x)		// ERROR "unsupported use of %pcrel_hi"
+	fence	rw				// ERROR "wrong number of arguments to fence"
+	fence	rx, w				// ERROR "bad ordering set rx"
+	ret
@@ -0,0 +1,162 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Go syntax equivalent of riscvgnu.s.
+
+// From runtime/textflag.h.
+#define NOSPLIT 4
+#define NOFRAME 512
+
+TEXT ·copy(SB),NOSPLIT|NOFRAME,$0
+	MOV	(SP), T0
+	MOV	8(SP), T1
+	MOV	16(SP), T2
+loop:
+	BGE	ZERO, T2, done
+	MOVBU	0(T1), T4
+	MOVB	T4, 0(T0)
+	ADDI	$1, T0, T0
+	ADDI	$1, T1, T1
+	ADDI	$-1, T2, T2
+	JMP	loop
+done:
+	RET
+
+TEXT helper<>(SB),NOSPLIT|NOFRAME,$0
+	ADDI	$0, ZERO, ZERO
+	MOV	$16, A0
+	MOV	$0x12345, A1
+	MOV	A0, A2
+	XOR	$-1, A2, A3
+	SUB	A3, ZERO, A4
+	SUBW	A4, ZERO, A5
+	ADDIW	$0, A5, A6
+	SEQZ	A6, A7
+	SNEZ	A7, S2
+	SLT	ZERO, S2, S3
+	SLT	S3, ZERO, S4
+	BEQ	A0, ZERO, end
+	BNE	A0, ZERO, end
+	BGE	ZERO, A0, end
+	BGE	A0, ZERO, end
+	BLT	A0, ZERO, end
+	BLT	ZERO, A0, end
+	BLT	A1, A0, end
+	BGE	A1, A0, end
+	BLTU	A1, A0, end
+	BGEU	A1, A0, end
+	BEQ	A0, A1, end
+	BLTU	A0, A1, end
+	ADD	A2, A1, A0
+	SUB	A2, A1, A0
+	SUBW	A2, A1, A0
+	SLL	A2, A1, A0
+	SLLI	$3, A1, A0
+	SRAI	$3, A1, A0
+	ANDI	$0xff, A1, A0
+	MUL	A2, A1, A0
+	DIVU	A2, A1, A0
+	REMW	A2, A1, A0
+	LUI	$0x12, A0
+	AUIPC	$0x12, A0
+	MOVW	-4(S0), A0
+	MOVWU	4(S0), A1
+	MOVH	2(SP), A2
+	MOVHU	2(SP), A3
+	MOVB	1(SP), A4
+	MOVW	A0, -4(S0)
+	MOVH	A2, 2(SP)
+	JALR	RA, (A0)
+	JALR	RA, (A0)
+	JALR	RA, 8(A0)
+	JALR	T0, 16(A0)
+	JMP	(T0)
+	CALL	·copy(SB)
+	CALL	runtime·memmove(SB)
+	CALL	·copy(SB)
+	JMP	·copy(SB)
+	JAL	T0, end
+	JMP	·copy(SB)
+end:
+	RET
+
+TEXT float<>(SB),NOSPLIT|NOFRAME,$0
+	MOVF	0(A0), F0
+	MOVD	8(A0), F1
+	MOVF	F0, 0(A1)
+	MOVD	F1, 8(A1)
+	FADDS	F1, F0, F2
+	FADDD.RTZ	F1, F0, F2
+	FMULD	F12, F11, F10
+	MOVF	F11, F10
+	MOVD	F11, F10
+	FNEGS	F11, F10
+	FNEGD	F11, F10
+	FSGNJXS	F11, F11, F10
+	FSGNJXD	F11, F11, F10
+	FCVTWS.RTZ	F0, A0
+	FCVTDL	A0, F0
+	FCVTLD.RNE	F0, A0
+	FEQD	F1, F0, A0
+	RET
+
+TEXT ·reloc(SB),NOSPLIT|NOFRAME,$0
+	MOV	$runtime·memmove(SB), A0
+	MOV	·copy+8(SB), A1
+	MOV	A3, ·table(SB)
+	MOV	$·table+16(SB), A4
+	MOV	$·copy(SB), A5
+	MOV	$runtime·memmove(SB), A6
+	RET
+
+TEXT compress<>(SB),NOSPLIT|NOFRAME,$0
+	ADD	A1, A0, A0
+	NORVC
+	ADD	A1, A0, A0
+	RVC
+	ADD	A1, A0, A0
+	C.ADD	A1, A0
+	C.LI	$5, A0
+	C.LW	4(A1), A0
+	C.SW	A0, 4(A1)
+	C.LDSP	8(SP), A0
+	C.SDSP	A0, 8(SP)
+	C.BEQZ	A0, ret
+	C.J	ret
+ret:
+	C.JR	RA
+
+TEXT cas<>(SB),NOSPLIT|NOFRAME,$0
+again:
+	LRW	(A0), A3
+	BNE	A3, A1, fail
+	SCW	A2, (A0), A4
+	BNE	A4, ZERO, again
+fail:
+	LRD	(A0), A3
+	SCD	A2, (A0), A4
+	RET
+
+TEXT priv<>(SB),NOSPLIT|NOFRAME,$0
+	FENCE
+	FENCE	$3, $1
+	FENCE	$15, $15
+	FENCETSO
+	FENCEI
+	PAUSE
+	SFENCEVMA
+	SFENCEVMA	A0
+	SFENCEVMA	A1, A0
+	HFENCEGVMA	A1, A0
+	CBOZERO	(A0)
+	CBOFLUSH	(A1)
+	WFI
+	MRET
+
+TEXT ·data(SB),NOSPLIT|NOFRAME,$0
+	WORD	$0x12345678
+	WORD	$7
+	WORD	$1
+	WORD	$0x9abcdef0
+	WORD	$0x12345678
@@ -0,0 +1,11 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+TEXT tls(SB),7,$0
+	MOV	tlsvar(SB), T6
+	ADD	TP, T6
+	MOV	(T6), A0
+	RET
+
+GLOBL tlsvar(SB), 256, $8
@@ -21,6 +21,7 @@ var (
 	Shared     = flag.Bool("shared", false, "generate code that can be linked into a shared library")
 	Dynlink    = flag.Bool("dynlink", false, "support references to Go symbols defined in other shared libraries")
 	AllErrors  = flag.Bool("e", false, "no limit on number of errors reported")
+	GNU        = flag.Bool("gnu", false, "accept GNU assembler syntax (riscv only)")
 )
 
 var (
@@ -104,6 +104,13 @@ func (in *Input) Next() ScanToken {
 		tok := in.Stack.Next()
 		switch tok {
 		case '#':
+			if *flags.GNU {
+				if !in.gnuHash() {
+					// A comment; the newline that ends it is read next.
+					return in.Next()
+				}
+				continue
+			}
 			if !in.beginningOfLine {
 				in.Error("'#' must be first item on line")
 			}
@@ -145,6 +152,44 @@ func (in *Input) hash() bool {
 	if tok != scanner.Ident {
 		in.expectText("expected identifier after '#'")
 	}
+	return in.directive()
+}
+
+// gnuHash is hash for GNU syntax, in which '#' also starts a comment that
+// runs to the end of the line. It reports whether it processed a
+// preprocessor directive; if not, it has skipped the comment and left the
+// token that ended it to be returned by the next call to Next.
+func (in *Input) gnuHash() bool {
+	tok := in.Stack.Next()
+	if in.beginningOfLine && tok == scanner.Ident && directives[in.Stack.Text()] {
+		in.beginningOfLine = in.directive()
+		return true
+	}
+	for tok != '\n' && tok != scanner.EOF {
+		tok = in.Stack.Next()
+	}
+	in.beginningOfLine = true
+	in.peek = true
+	in.peekToken = tok
+	in.peekText = in.Stack.Text()
+	return false
+}
+
+// directives is the set of words that may follow '#' in a preprocessor
+// directive.
+var directives = map[string]bool{
+	"define":  true,
+	"else":    true,
+	"endif":   true,
+	"ifdef":   true,
+	"ifndef":  true,
+	"include": true,
+	"line":    true,
+	"undef":   true,
+}
+
+// directive processes the preprocessor directive named by the current token.
+func (in *Input) directive() bool {
 	if !in.enabled() {
 		// Can only start including again if we are at #else or #endif but also
 		// need to keep track of nested #if[n]defs.
@@ -18,6 +18,7 @@ import (
 
 	"cmd/internal/bio"
 	"cmd/internal/obj"
+	"cmd/internal/sys"
 )
 
 func main() {
@@ -32,6 +33,16 @@ func main() {
 	}
 
 	flags.Parse()
+	if *flags.GNU && architecture.Family != sys.RISCV {
+		log.Fatalf("-gnu is not supported on %s", GOARCH)
+	}
+	if architecture.Family == sys.RISCV {
+		// Let assembly code test for the extensions in GORISCV,
+		// as in #ifdef GORISCV_zbb.
+		for _, ext := range obj.GORISCVExtensions() {
+			flags.D = append(flags.D, "GORISCV_"+ext)
+		}
+	}
 
 	ctxt := obj.Linknew(architecture.LinkArch)
 	if *flags.PrintOut {
@@ -2686,7 +2686,13 @@ func intrinsicInit() {
 		/******** math ********/
 		intrinsicKey{"math", "Sqrt"}: enableOnArch(func(s *state, n *Node, args []*ssa.Value) *ssa.Value {
 			return s.newValue1(ssa.OpSqrt, Types[TFLOAT64], args[0])
-		}, sys.AMD64, sys.ARM, sys.ARM64, sys.MIPS, sys.PPC64, sys.S390X),
+		}, sys.AMD64, sys.ARM, sys.ARM64, sys.MIPS, sys.PPC64, sys.RISCV, sys.S390X),
+		intrinsicKey{"math", "Abs"}: enableOnArch(func(s *state, n *Node, args []*ssa.Value) *ssa.Value {
+			return s.newValue1(ssa.OpAbs, Types[TFLOAT64], args[0])
+		}, sys.RISCV),
+		intrinsicKey{"math", "Copysign"}: enableOnArch(func(s *state, n *Node, args []*ssa.Value) *ssa.Value {
+			return s.newValue2(ssa.OpCopysign, Types[TFLOAT64], args[0], args[1])
+		}, sys.RISCV),
 	}
 
 	// aliases internal to runtime/internal/atomic
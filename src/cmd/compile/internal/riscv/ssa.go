@@ -177,6 +177,7 @@ func ssaGenValue(s *gc.SSAGenState, v *ssa.Value) {
 		ssa.OpRISCVFADDS, ssa.OpRISCVFSUBS, ssa.OpRISCVFMULS, ssa.OpRISCVFDIVS,
 		ssa.OpRISCVFEQS, ssa.OpRISCVFNES, ssa.OpRISCVFLTS, ssa.OpRISCVFLES,
 		ssa.OpRISCVFADDD, ssa.OpRISCVFSUBD, ssa.OpRISCVFMULD, ssa.OpRISCVFDIVD,
+		ssa.OpRISCVFSGNJD, ssa.OpRISCVFSGNJXD,
 		ssa.OpRISCVFEQD, ssa.OpRISCVFNED, ssa.OpRISCVFLTD, ssa.OpRISCVFLED:
 		r := v.Reg()
 		r1 := v.Args[0].Reg()
@@ -202,6 +203,12 @@ func ssaGenValue(s *gc.SSAGenState, v *ssa.Value) {
 		p.From.Reg = v.Args[0].Reg()
 		p.To.Type = obj.TYPE_REG
 		p.To.Reg = v.Reg()
+		switch v.Op {
+		case ssa.OpRISCVFCVTWS, ssa.OpRISCVFCVTLS, ssa.OpRISCVFCVTWD, ssa.OpRISCVFCVTLD:
+			// Go truncates when converting floats to integers. Don't
+			// depend on whatever is in frm.
+			p.Scond = riscv.RM_RTZ
+		}
 	case ssa.OpRISCVADDI, ssa.OpRISCVXORI, ssa.OpRISCVORI, ssa.OpRISCVANDI,
 		ssa.OpRISCVSLLI, ssa.OpRISCVSRAI, ssa.OpRISCVSRLI, ssa.OpRISCVSLTI,
 		ssa.OpRISCVSLTIU, ssa.OpRISCVADDIW:
@@ -45,8 +45,9 @@ func init() {
 
 		// Add general purpose registers to gpMask.
 		switch r {
-		// ZERO, g, and TMP are not in any gp mask.
-		case riscv.REG_ZERO, riscv.REG_G, riscv.REG_TMP:
+		// ZERO, TP, g, and TMP are not in any gp mask. TP is the
+		// thread pointer and belongs to the C ABI.
+		case riscv.REG_ZERO, riscv.REG_TP, riscv.REG_G, riscv.REG_TMP:
 		case riscv.REG_SP:
 			gpspMask |= mask
 			gpspsbMask |= mask
@@ -310,6 +311,8 @@ func init() {
 		{name: "FDIVD", argLength: 2, reg: fp21, asm: "FDIVD", commutative: false, typ: "Float64"},                       // arg0 / arg1
 		{name: "FSQRTD", argLength: 1, reg: fp11, asm: "FSQRTD", typ: "Float64"},                                         // sqrt(arg0)
 		{name: "FNEGD", argLength: 1, reg: fp11, asm: "FNEGD", typ: "Float64"},                                           // -arg0
+		{name: "FSGNJD", argLength: 2, reg: fp21, asm: "FSGNJD", typ: "Float64"},                                         // magnitude of arg0 with the sign of arg1
+		{name: "FSGNJXD", argLength: 2, reg: fp21, asm: "FSGNJXD", typ: "Float64"},                                       // arg0 with its sign xored with the sign of arg1
 		{name: "FMVDX", argLength: 1, reg: gpfp, asm: "FMVDX", typ: "Float64"},                                           // reinterpret arg0 as float
 		{name: "FCVTDW", argLength: 1, reg: gpfp, asm: "FCVTDW", typ: "Float64"},                                         // float64(arg0)
 		{name: "FCVTDL", argLength: 1, reg: gpfp, asm: "FCVTDL", typ: "Float64"},                                         // float64(arg0)
@@ -242,7 +242,9 @@ var genericOps = []opData{
 	{name: "Bswap32", argLength: 1}, // Swap bytes
 	{name: "Bswap64", argLength: 1}, // Swap bytes
 
-	{name: "Sqrt", argLength: 1}, // sqrt(arg0), float64 only
+	{name: "Sqrt", argLength: 1},     // sqrt(arg0), float64 only
+	{name: "Abs", argLength: 1},      // |arg0|, float64 only
+	{name: "Copysign", argLength: 2}, // magnitude of arg0 with the sign of arg1, float64 only
 
 	// Data movement, max argument length for Phi is indefinite so just pick
 	// a really large number
@@ -1468,6 +1468,8 @@ const (
 	OpRISCVFDIVD
 	OpRISCVFSQRTD
 	OpRISCVFNEGD
+	OpRISCVFSGNJD
+	OpRISCVFSGNJXD
 	OpRISCVFMVDX
 	OpRISCVFCVTDW
 	OpRISCVFCVTDL
@@ -1873,6 +1875,8 @@ const (
 	OpBswap32
 	OpBswap64
 	OpSqrt
+	OpAbs
+	OpCopysign
 	OpPhi
 	OpCopy
 	OpConvert
@@ -17064,11 +17068,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AADD,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17080,10 +17084,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AADDI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17095,10 +17099,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AADDIW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17109,11 +17113,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASUB,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17123,10 +17127,10 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17138,11 +17142,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AMUL,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17154,11 +17158,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AMULW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17170,11 +17174,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AMULH,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17186,11 +17190,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AMULHU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17201,11 +17205,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ADIV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17216,11 +17220,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ADIVU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17231,11 +17235,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ADIVW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17246,11 +17250,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ADIVUW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17261,11 +17265,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AREM,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17276,11 +17280,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AREMU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17291,11 +17295,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AREMW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17306,11 +17310,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AREMUW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17323,10 +17327,10 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17339,7 +17343,7 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17352,7 +17356,7 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17365,7 +17369,7 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17378,7 +17382,7 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17391,7 +17395,7 @@ var opcodeTable = [...]opInfo{
 		asm:               riscv.AMOV,
 		reg: regInfo{
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17404,10 +17408,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVB,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17420,10 +17424,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVH,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17436,10 +17440,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17452,10 +17456,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17468,10 +17472,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVBU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17484,10 +17488,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVHU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17500,10 +17504,10 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVWU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17516,8 +17520,8 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVB,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{1, 1073739519},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{1, 1006630655},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17530,8 +17534,8 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVH,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{1, 1073739519},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{1, 1006630655},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17544,8 +17548,8 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{1, 1073739519},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{1, 1006630655},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17558,8 +17562,8 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{1, 1073739519},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{1, 1006630655},          // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17572,7 +17576,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVB,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17585,7 +17589,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVH,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17598,7 +17602,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17611,7 +17615,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928517375}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 SB
 			},
 		},
 	},
@@ -17622,11 +17626,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLL,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17637,11 +17641,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASRA,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17652,11 +17656,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASRL,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17668,10 +17672,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLLI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17683,10 +17687,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASRAI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17698,10 +17702,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASRLI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17713,11 +17717,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AXOR,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17729,10 +17733,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AXORI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17744,11 +17748,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AOR,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17760,10 +17764,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AORI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17775,11 +17779,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AAND,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17791,10 +17795,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AANDI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17805,10 +17809,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASEQZ,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17819,10 +17823,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASNEZ,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17833,11 +17837,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLT,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17849,10 +17853,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLTI,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17863,11 +17867,11 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLTU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17879,10 +17883,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.ASLTIU,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17893,10 +17897,10 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AMOV,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -17907,7 +17911,7 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		call:         true,
 		reg: regInfo{
-			clobbers: 9223372035781033215, // S0 S1 A0 A1 A2 A3 A4 A5 GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			clobbers: 9223372035781031167, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 		},
 	},
 	{
@@ -17919,9 +17923,9 @@ var opcodeTable = [...]opInfo{
 		reg: regInfo{
 			inputs: []inputInfo{
 				{1, 524288},     // CTXT
-				{0, 1073739519}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630655}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
-			clobbers: 9223372035781033215, // S0 S1 A0 A1 A2 A3 A4 A5 GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			clobbers: 9223372035781031167, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 		},
 	},
 	{
@@ -17931,7 +17935,7 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		call:         true,
 		reg: regInfo{
-			clobbers: 9223372035781033215, // S0 S1 A0 A1 A2 A3 A4 A5 GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			clobbers: 9223372035781031167, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 		},
 	},
 	{
@@ -17941,7 +17945,7 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		call:         true,
 		reg: regInfo{
-			clobbers: 9223372035781033215, // S0 S1 A0 A1 A2 A3 A4 A5 GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			clobbers: 9223372035781031167, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 		},
 	},
 	{
@@ -17952,9 +17956,9 @@ var opcodeTable = [...]opInfo{
 		call:         true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
-			clobbers: 9223372035781033215, // S0 S1 A0 A1 A2 A3 A4 A5 GP g T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			clobbers: 9223372035781031167, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 g T3 T4 T5 FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 		},
 	},
 	{
@@ -17966,7 +17970,7 @@ var opcodeTable = [...]opInfo{
 		reg: regInfo{
 			inputs: []inputInfo{
 				{0, 4},          // A0
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			clobbers: 4, // A0
 		},
@@ -17982,7 +17986,7 @@ var opcodeTable = [...]opInfo{
 			inputs: []inputInfo{
 				{0, 4},          // A0
 				{1, 8},          // A1
-				{2, 1073738991}, // S0 S1 A0 A1 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{2, 1006630127}, // S0 S1 A0 A1 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			clobbers: 28, // A0 A1 A2
 		},
@@ -18023,7 +18027,7 @@ var opcodeTable = [...]opInfo{
 		faultOnNilArg0: true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739519}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630655}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18134,7 +18138,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFMVSX,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18148,7 +18152,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFCVTSW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18162,7 +18166,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFCVTSL,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18179,7 +18183,7 @@ var opcodeTable = [...]opInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18193,7 +18197,7 @@ var opcodeTable = [...]opInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18206,7 +18210,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVF,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18222,7 +18226,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVF,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 		},
@@ -18239,7 +18243,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18255,7 +18259,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18270,7 +18274,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18285,7 +18289,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18379,6 +18383,36 @@ var opcodeTable = [...]opInfo{
 			},
 		},
 	},
+	{
+		name:         "FSGNJD",
+		argLen:       2,
+		clobberFlags: true,
+		asm:          riscv.AFSGNJD,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			},
+			outputs: []outputInfo{
+				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			},
+		},
+	},
+	{
+		name:         "FSGNJXD",
+		argLen:       2,
+		clobberFlags: true,
+		asm:          riscv.AFSGNJXD,
+		reg: regInfo{
+			inputs: []inputInfo{
+				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			},
+			outputs: []outputInfo{
+				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
+			},
+		},
+	},
 	{
 		name:         "FMVDX",
 		argLen:       1,
@@ -18386,7 +18420,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFMVDX,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18400,7 +18434,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFCVTDW,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18414,7 +18448,7 @@ var opcodeTable = [...]opInfo{
 		asm:          riscv.AFCVTDL,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18431,7 +18465,7 @@ var opcodeTable = [...]opInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18445,7 +18479,7 @@ var opcodeTable = [...]opInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18486,7 +18520,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVD,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 			},
 			outputs: []outputInfo{
 				{0, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
@@ -18502,7 +18536,7 @@ var opcodeTable = [...]opInfo{
 		asm:            riscv.AMOVD,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 9223372037928515327}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5 SB
+				{0, 9223372037861406463}, // S0 S1 A0 A1 A2 A3 A4 A5 SP GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5 SB
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 		},
@@ -18519,7 +18553,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18535,7 +18569,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18550,7 +18584,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18565,7 +18599,7 @@ var opcodeTable = [...]opInfo{
 				{1, 9223372034707292160}, // FS0 FS1 FA0 FA1 FA2 FA3 FA4 FA5 FT0 FT1 FT2 FT3 FT4 FT5 FT6 FT7 FA6 FA7 FS2 FS3 FS4 FS5 FS6 FS7 FS8 FS9 FS10 FS11 FT8 FT9 FT10 FT11
 			},
 			outputs: []outputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18575,8 +18609,8 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
-				{1, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
+				{1, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18586,7 +18620,7 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -18596,7 +18630,7 @@ var opcodeTable = [...]opInfo{
 		clobberFlags: true,
 		reg: regInfo{
 			inputs: []inputInfo{
-				{0, 1073739007}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 S11 T3 T4 T5
+				{0, 1006630143}, // S0 S1 A0 A1 A2 A3 A4 A5 GP T0 T1 T2 A6 A7 S2 S3 CTXT S5 S6 S7 S8 S9 S10 T3 T4 T5
 			},
 		},
 	},
@@ -22422,6 +22456,16 @@ var opcodeTable = [...]opInfo{
 		argLen:  1,
 		generic: true,
 	},
+	{
+		name:    "Abs",
+		argLen:  1,
+		generic: true,
+	},
+	{
+		name:    "Copysign",
+		argLen:  2,
+		generic: true,
+	},
 	{
 		name:    "Phi",
 		argLen:  -1,
@@ -23513,7 +23557,7 @@ var registersRISCV = [...]Register{
 	{8, riscv.REG_ZERO, "ZERO"},
 	{9, riscv.REGSP, "SP"},
 	{10, riscv.REG_GP, "GP"},
-	{11, riscv.REGG, "g"},
+	{11, riscv.REG_TP, "TP"},
 	{12, riscv.REG_T0, "T0"},
 	{13, riscv.REG_T1, "T1"},
 	{14, riscv.REG_T2, "T2"},
@@ -23528,7 +23572,7 @@ var registersRISCV = [...]Register{
 	{23, riscv.REG_S8, "S8"},
 	{24, riscv.REG_S9, "S9"},
 	{25, riscv.REG_S10, "S10"},
-	{26, riscv.REG_S11, "S11"},
+	{26, riscv.REGG, "g"},
 	{27, riscv.REG_T3, "T3"},
 	{28, riscv.REG_T4, "T4"},
 	{29, riscv.REG_T5, "T5"},
@@ -23567,7 +23611,7 @@ var registersRISCV = [...]Register{
 	{62, riscv.REG_FT11, "FT11"},
 	{63, 0, "SB"},
 }
-var gpRegMaskRISCV = regMask(1073739007)
+var gpRegMaskRISCV = regMask(1006630143)
 var fpRegMaskRISCV = regMask(9223372034707292160)
 var specialRegMaskRISCV = regMask(0)
 var framepointerRegRISCV = int8(-1)
@@ -8,6 +8,8 @@ import "math"
 var _ = math.MinInt8 // in case not otherwise used
 func rewriteValueRISCV(v *Value, config *Config) bool {
 	switch v.Op {
+	case OpAbs:
+		return rewriteValueRISCV_OpAbs(v, config)
 	case OpAdd16:
 		return rewriteValueRISCV_OpAdd16(v, config)
 	case OpAdd32:
@@ -64,6 +66,8 @@ func rewriteValueRISCV(v *Value, config *Config) bool {
 		return rewriteValueRISCV_OpConstNil(v, config)
 	case OpConvert:
 		return rewriteValueRISCV_OpConvert(v, config)
+	case OpCopysign:
+		return rewriteValueRISCV_OpCopysign(v, config)
 	case OpCvt32Fto32:
 		return rewriteValueRISCV_OpCvt32Fto32(v, config)
 	case OpCvt32Fto64:
@@ -543,6 +547,20 @@ func rewriteValueRISCV(v *Value, config *Config) bool {
 	}
 	return false
 }
+func rewriteValueRISCV_OpAbs(v *Value, config *Config) bool {
+	b := v.Block
+	_ = b
+	// match: (Abs x)
+	// cond:
+	// result: (FSGNJXD x x)
+	for {
+		x := v.Args[0]
+		v.reset(OpRISCVFSGNJXD)
+		v.AddArg(x)
+		v.AddArg(x)
+		return true
+	}
+}
 func rewriteValueRISCV_OpAdd16(v *Value, config *Config) bool {
 	b := v.Block
 	_ = b
@@ -966,6 +984,21 @@ func rewriteValueRISCV_OpConvert(v *Value, config *Config) bool {
 		return true
 	}
 }
+func rewriteValueRISCV_OpCopysign(v *Value, config *Config) bool {
+	b := v.Block
+	_ = b
+	// match: (Copysign x y)
+	// cond:
+	// result: (FSGNJD x y)
+	for {
+		x := v.Args[0]
+		y := v.Args[1]
+		v.reset(OpRISCVFSGNJD)
+		v.AddArg(x)
+		v.AddArg(y)
+		return true
+	}
+}
 func rewriteValueRISCV_OpCvt32Fto32(v *Value, config *Config) bool {
 	b := v.Block
 	_ = b
@@ -28,6 +28,7 @@ var (
 	goos                   string
 	goarm                  string
 	go386                  string
+	goriscv                string
 	goroot                 string
 	goroot_final           string
 	goextlinkenabled       string
@@ -77,11 +78,21 @@ var okgoos = []string{
 	"freebsd",
 	"nacl",
 	"netbsd",
+	"none",
 	"openbsd",
 	"plan9",
 	"windows",
 }
 
+// The optional extensions that may follow the base instruction set
+// in $GORISCV, as in GC_zbb_zkn.
+var okgoriscvext = []string{
+	"zbb",
+	"zbc",
+	"zic64b",
+	"zkn",
+}
+
 // find reports the first index of p in l[0:n], or else -1.
 func find(p string, l []string) int {
 	for i, s := range l {
@@ -139,6 +150,21 @@ func xinit() {
 	}
 	go386 = b
 
+	b = os.Getenv("GORISCV")
+	if b == "" {
+		b = "GC"
+	}
+	exts := strings.Split(b, "_")
+	if exts[0] != "G" && exts[0] != "GC" {
+		fatal("unknown $GORISCV %s", b)
+	}
+	for _, ext := range exts[1:] {
+		if find(ext, okgoriscvext) < 0 {
+			fatal("unknown extension %s in $GORISCV %s", ext, b)
+		}
+	}
+	goriscv = b
+
 	p := pathf("%s/src/all.bash", goroot)
 	if !isfile(p) {
 		fatal("$GOROOT is not set correctly or not exported\n"+
@@ -225,6 +251,7 @@ func xinit() {
 	os.Setenv("GOHOSTARCH", gohostarch)
 	os.Setenv("GOHOSTOS", gohostos)
 	os.Setenv("GOOS", goos)
+	os.Setenv("GORISCV", goriscv)
 	os.Setenv("GOROOT", goroot)
 	os.Setenv("GOROOT_FINAL", goroot_final)
 
@@ -996,6 +1023,9 @@ func cmdenv() {
 	if goarch == "386" {
 		xprintf(format, "GO386", go386)
 	}
+	if goarch == "riscv" {
+		xprintf(format, "GORISCV", goriscv)
+	}
 
 	if *path {
 		sep := ":"
@@ -1102,6 +1132,7 @@ var cgoEnabled = map[string]bool{
 	"freebsd/386":     true,
 	"freebsd/amd64":   true,
 	"freebsd/arm":     false,
+	"freebsd/riscv":   false,
 	"linux/386":       true,
 	"linux/amd64":     true,
 	"linux/arm":       true,
@@ -1124,6 +1155,7 @@ var cgoEnabled = map[string]bool{
 	"netbsd/386":      true,
 	"netbsd/amd64":    true,
 	"netbsd/arm":      true,
+	"none/riscv":      false,
 	"openbsd/386":     true,
 	"openbsd/amd64":   true,
 	"openbsd/arm":     false,
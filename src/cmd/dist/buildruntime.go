@@ -21,6 +21,8 @@ import (
 //	const TheVersion = <version>
 //	const Goexperiment = <goexperiment>
 //	const StackGuardMultiplier = <multiplier value>
+//	const GoriscvRVC = <1 if $GORISCV includes the C extension, else 0>
+//	const GoriscvCacheLineSize = <cache line size implied by $GORISCV>
 //
 func mkzversion(dir, file string) {
 	// FIXME: We need large stacks until we can link in the runtime,
@@ -34,7 +36,9 @@ func mkzversion(dir, file string) {
 			"const DefaultGoroot = `%s`\n"+
 			"const TheVersion = `%s`\n"+
 			"const Goexperiment = `%s`\n"+
-			"const StackGuardMultiplier = %d + 20 * GoarchRiscv\n\n", goroot_final, findgoversion(), os.Getenv("GOEXPERIMENT"), stackGuardMultiplier())
+			"const StackGuardMultiplier = %d + 20 * GoarchRiscv\n"+
+			"const GoriscvRVC = %d\n"+
+			"const GoriscvCacheLineSize = %d\n\n", goroot_final, findgoversion(), os.Getenv("GOEXPERIMENT"), stackGuardMultiplier(), goriscvRVC(), goriscvCacheLineSize())
 
 	writefile(out, file, writeSkipSame)
 }
@@ -46,6 +50,7 @@ func mkzversion(dir, file string) {
 //	const defaultGOROOT = <goroot>
 //	const defaultGO386 = <go386>
 //	const defaultGOARM = <goarm>
+//	const defaultGORISCV = <goriscv>
 //	const defaultGOOS = runtime.GOOS
 //	const defaultGOARCH = runtime.GOARCH
 //	const defaultGO_EXTLINK_ENABLED = <goextlinkenabled>
@@ -73,17 +78,40 @@ func mkzbootstrap(file string) {
 			"const defaultGOROOT = `%s`\n"+
 			"const defaultGO386 = `%s`\n"+
 			"const defaultGOARM = `%s`\n"+
+			"const defaultGORISCV = `%s`\n"+
 			"const defaultGOOS = runtime.GOOS\n"+
 			"const defaultGOARCH = runtime.GOARCH\n"+
 			"const defaultGO_EXTLINK_ENABLED = `%s`\n"+
 			"const version = `%s`\n"+
 			"const stackGuardMultiplier = %d\n"+
 			"const goexperiment = `%s`\n",
-		goroot_final, go386, goarm, goextlinkenabled, findgoversion(), stackGuardMultiplier(), os.Getenv("GOEXPERIMENT"))
+		goroot_final, go386, goarm, goriscv, goextlinkenabled, findgoversion(), stackGuardMultiplier(), os.Getenv("GOEXPERIMENT"))
 
 	writefile(out, file, writeSkipSame)
 }
 
+// goriscvRVC reports whether $GORISCV includes the C extension, as
+// 1 or 0 so that runtime/internal/sys can compute PCQuantum from it.
+func goriscvRVC() int {
+	if strings.Split(goriscv, "_")[0] == "GC" {
+		return 1
+	}
+	return 0
+}
+
+// goriscvCacheLineSize returns the cache line size the riscv runtime
+// pads to. Only the Zic64b extension promises a size, 64 bytes; without
+// it, pad to 128 bytes so that cores with larger lines do not suffer
+// false sharing.
+func goriscvCacheLineSize() int {
+	for _, ext := range strings.Split(goriscv, "_")[1:] {
+		if ext == "zic64b" {
+			return 64
+		}
+	}
+	return 128
+}
+
 // stackGuardMultiplier returns a multiplier to apply to the default
 // stack guard size. Larger multipliers are used for non-optimized
 // builds that have larger stack frames.
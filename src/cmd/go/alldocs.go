@@ -1127,6 +1127,15 @@
 // 	GO386
 // 		For GOARCH=386, the floating point instruction set.
 // 		Valid values are 387, sse2.
+// 	GORISCV
+// 		For GOARCH=riscv, the instruction set for which to compile.
+// 		Valid values are G and GC (G plus compressed instructions),
+// 		optionally followed by the extensions _zbb (basic bit manipulation),
+// 		_zbc (carry-less multiplication), _zic64b (64-byte cache blocks)
+// 		and _zkn (scalar cryptography), as in GC_zbb_zkn. Listed extensions
+// 		are assumed to be present; on Linux, the standard library also
+// 		uses any it detects at run time.
+// 		The runtime depends on it, so it is fixed when make.bash runs.
 //
 // Special-purpose environment variables:
 //
@@ -271,6 +271,10 @@ func BuildModeInit() {
 		switch platform {
 		case "darwin/arm", "darwin/arm64":
 			codegenArg = "-shared"
+		case "linux/riscv":
+			// The riscv runtime cannot yet call or be called
+			// from C, and the linker cannot link externally.
+			base.Fatalf("-buildmode=c-archive not supported on %s\n", platform)
 		default:
 			switch cfg.Goos {
 			case "dragonfly", "freebsd", "linux", "netbsd", "openbsd", "solaris":
@@ -2179,7 +2183,7 @@ func (gcToolchain) gc(b *Builder, p *load.Package, archive, obj string, asmhdr b
 	extFiles := len(p.CgoFiles) + len(p.CFiles) + len(p.CXXFiles) + len(p.MFiles) + len(p.FFiles) + len(p.SFiles) + len(p.SysoFiles) + len(p.SwigFiles) + len(p.SwigCXXFiles)
 	if p.Standard {
 		switch p.ImportPath {
-		case "bytes", "net", "os", "runtime/pprof", "sync", "time":
+		case "bytes", "internal/cpu", "net", "os", "runtime/pprof", "sync", "time":
 			extFiles++
 		}
 	}
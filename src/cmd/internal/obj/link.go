@@ -686,6 +686,11 @@ const (
 	// R_RISCV_PCREL_STYPE resolves a 32-bit PC-relative address using an AUIPC +
 	// S-type instruction pair.
 	R_RISCV_PCREL_STYPE
+
+	// R_RISCV_TLS_LE resolves the 32-bit offset of a thread-local symbol from
+	// the thread pointer (TP) using a LUI + I-type instruction pair. It is
+	// used to implement the "local exec" model for tls access.
+	R_RISCV_TLS_LE
 )
 
 // IsDirectJump returns whether r is a relocation for a direct jump.
@@ -834,6 +839,7 @@ const (
 	Hlinux
 	Hnacl
 	Hnetbsd
+	Hnone
 	Hopenbsd
 	Hplan9
 	Hsolaris
@@ -855,6 +861,8 @@ func (h *HeadType) Set(s string) error {
 		*h = Hnacl
 	case "netbsd":
 		*h = Hnetbsd
+	case "none":
+		*h = Hnone
 	case "openbsd":
 		*h = Hopenbsd
 	case "plan9":
@@ -885,6 +893,8 @@ func (h *HeadType) String() string {
 		return "nacl"
 	case Hnetbsd:
 		return "netbsd"
+	case Hnone:
+		return "none"
 	case Hopenbsd:
 		return "openbsd"
 	case Hplan9:
@@ -18,7 +18,9 @@ var Anames = []string{
 	"BGE",
 	"BGEU",
 	"FENCE",
+	"FENCETSO",
 	"FENCEI",
+	"PAUSE",
 	"ADDI",
 	"SLTI",
 	"SLTIU",
@@ -171,6 +173,43 @@ var Anames = []string{
 	"FLTD",
 	"FLED",
 	"FCLASSD",
+	"CLWSP",
+	"CLDSP",
+	"CFLDSP",
+	"CSWSP",
+	"CSDSP",
+	"CFSDSP",
+	"CLW",
+	"CLD",
+	"CFLD",
+	"CSW",
+	"CSD",
+	"CFSD",
+	"CJ",
+	"CJR",
+	"CJALR",
+	"CBEQZ",
+	"CBNEZ",
+	"CLI",
+	"CLUI",
+	"CADDI",
+	"CADDIW",
+	"CADDI16SP",
+	"CADDI4SPN",
+	"CSLLI",
+	"CSRLI",
+	"CSRAI",
+	"CANDI",
+	"CMV",
+	"CADD",
+	"CAND",
+	"COR",
+	"CXOR",
+	"CSUB",
+	"CADDW",
+	"CSUBW",
+	"CNOP",
+	"CEBREAK",
 	"CSRRW",
 	"CSRRS",
 	"CSRRC",
@@ -181,13 +220,44 @@ var Anames = []string{
 	"SCALL",
 	"EBREAK",
 	"SBREAK",
-	"ERET",
+	"MRET",
 	"SRET",
-	"MRTS",
-	"MRTH",
-	"HRTS",
 	"WFI",
-	"SFENCEVM",
+	"SFENCEVMA",
+	"HFENCEVVMA",
+	"HFENCEGVMA",
+	"CBOCLEAN",
+	"CBOFLUSH",
+	"CBOINVAL",
+	"CBOZERO",
+	"ANDN",
+	"ORN",
+	"XNOR",
+	"ROL",
+	"ROR",
+	"RORI",
+	"ROLW",
+	"RORW",
+	"RORIW",
+	"REV8",
+	"CLMUL",
+	"CLMULH",
+	"CLMULR",
+	"AES64DS",
+	"AES64DSM",
+	"AES64ES",
+	"AES64ESM",
+	"AES64IM",
+	"AES64KS1I",
+	"AES64KS2",
+	"SHA256SIG0",
+	"SHA256SIG1",
+	"SHA256SUM0",
+	"SHA256SUM1",
+	"SHA512SIG0",
+	"SHA512SIG1",
+	"SHA512SUM0",
+	"SHA512SUM1",
 	"WORD",
 	"FNEGD",
 	"FNEGS",
@@ -204,4 +274,6 @@ var Anames = []string{
 	"MOVWU",
 	"SEQZ",
 	"SNEZ",
+	"NORVC",
+	"RVC",
 }
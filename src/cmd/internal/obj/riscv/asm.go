@@ -39,11 +39,12 @@ package riscv
 import (
 	"cmd/internal/obj"
 	"fmt"
-	"os"
+	"strings"
 )
 
-// TODO(sorear): replace this with a proper GORISCV=G, GORISCV=GC facility
-var GORISCVRVC bool = os.Getenv("GORISCVRVC") != "no"
+// GORISCVRVC reports whether the C extension may be used, that is,
+// whether the base instruction set in GORISCV is GC rather than G.
+var GORISCVRVC bool = strings.Split(obj.GORISCV, "_")[0] == "GC"
 
 // stackOffset updates Addr offsets based on the current stack size.
 //
@@ -189,9 +190,16 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 		switch p.As {
 		case AADD, ASUB, ASLL, AXOR, ASRL, ASRA, AOR, AAND, AMUL, AMULH,
 			AMULHU, AMULHSU, AMULW, ADIV, ADIVU, AREM, AREMU, ADIVW,
-			ADIVUW, AREMW, AREMUW, AADDW:
+			ADIVUW, AREMW, AREMUW, AADDW,
+			AANDN, AORN, AXNOR, AROL, AROR, AROLW, ARORW,
+			ACLMUL, ACLMULH, ACLMULR,
+			ACADD, ACAND, ACOR, ACXOR, ACSUB, ACADDW, ACSUBW,
+			ACADDI, ACADDIW, ACADDI16SP, ACSLLI, ACSRLI, ACSRAI, ACANDI:
 			p.From3.Type = obj.TYPE_REG
 			p.From3.Reg = p.To.Reg
+		case ACADDI4SPN:
+			p.From3.Type = obj.TYPE_REG
+			p.From3.Reg = REG_SP
 		}
 	}
 
@@ -217,6 +225,10 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 			p.As = ASRLI
 		case AXOR:
 			p.As = AXORI
+		case AROR:
+			p.As = ARORI
+		case ARORW:
+			p.As = ARORIW
 		}
 	}
 
@@ -236,7 +248,7 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 			case obj.NAME_AUTO, obj.NAME_PARAM, obj.NAME_NONE:
 				p.As = AJALR
 				lowerjalr(p)
-			case obj.NAME_EXTERN:
+			case obj.NAME_EXTERN, obj.NAME_STATIC:
 				// Handled in preprocess.
 			default:
 				ctxt.Diag("progedit: unsupported name %d for %v", p.To.Name, p)
@@ -261,7 +273,118 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 	case AJALR:
 		lowerjalr(p)
 
-	case obj.AUNDEF, AECALL, AEBREAK, ASCALL, ARDCYCLE, ARDTIME, ARDINSTRET:
+	case ALRW, ALRD:
+		// LRW (Rs), Rd -> LRW ZERO, Rs, Rd
+		if p.From.Type != obj.TYPE_MEM || p.From.Name != obj.NAME_NONE || p.From.Offset != 0 {
+			ctxt.Diag("%v\tLR needs a register-indirect address", p)
+		}
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: p.From.Reg}
+		p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+	case ASCW, ASCD:
+		// SCW Rs2, (Rs1), Rd -> SCW Rs2, Rs1, Rd
+		if p.From3.Type != obj.TYPE_MEM || p.From3.Name != obj.NAME_NONE || p.From3.Offset != 0 {
+			ctxt.Diag("%v\tSC needs a register-indirect address", p)
+		}
+		p.From3.Type = obj.TYPE_REG
+
+	case AAUIPC:
+		// AUIPC $sym(SB), R is the high half of a PC-relative pair
+		// written out by hand. The symbol is extracted for a
+		// relocation later, as for the pairs generated below.
+		if p.From.Type == obj.TYPE_ADDR && p.Mark&(NEED_PCREL_ITYPE_RELOC|NEED_PCREL_STYPE_RELOC) != 0 {
+			p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: p.From.Offset, Sym: p.From.Sym}
+		}
+
+	// Explicitly compressed instructions use the same operand layout as
+	// the instructions they are compressed from.
+	case ACJ:
+		// CJ label -> JAL ZERO, label
+		p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+	case ACJR, ACJALR:
+		// CJR rs -> JALR $0, rs, ZERO
+		// CJALR rs -> JALR $0, rs, RA
+		*p.From3 = p.To
+		p.From = obj.Addr{Type: obj.TYPE_CONST}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		if p.As == ACJALR {
+			p.To.Reg = REG_RA
+		}
+	case ACBEQZ, ACBNEZ:
+		// CBEQZ rs, label -> BEQ rs, ZERO, label
+		p.Reg = REG_ZERO
+	case ACLI:
+		// CLI $imm, rd -> ADDI $imm, ZERO, rd
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+	case ACMV:
+		// CMV rs, rd -> ADDI $0, rs, rd
+		*p.From3 = p.From
+		p.From = obj.Addr{Type: obj.TYPE_CONST}
+	case ACNOP:
+		// CNOP -> ADDI $0, ZERO, ZERO
+		p.From = obj.Addr{Type: obj.TYPE_CONST}
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+	case ACEBREAK:
+		i, ok := encode(AEBREAK)
+		if !ok {
+			panic("progedit: tried to rewrite nonexistent instruction")
+		}
+		p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: i.csr}
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+
+	case AFENCE:
+		// FENCE -> FENCE $0xf, $0xf
+		// FENCE $pred, $succ orders the accesses in pred before
+		// those in succ. Each is a 4-bit I/O/R/W set, most
+		// significant bit first.
+		pred, succ := int64(0xf), int64(0xf)
+		if p.From.Type != obj.TYPE_NONE || p.To.Type != obj.TYPE_NONE {
+			if p.From.Type != obj.TYPE_CONST || p.To.Type != obj.TYPE_CONST {
+				ctxt.Diag("%v\tFENCE needs a predecessor and successor set", p)
+			}
+			pred, succ = p.From.Offset, p.To.Offset
+			if pred&^0xf != 0 || succ&^0xf != 0 {
+				ctxt.Diag("%v\tFENCE sets must be in the range [0, 15]", p)
+			}
+		}
+		p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: (pred&0xf)<<4 | succ&0xf}
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+
+	case ASFENCEVMA, AHFENCEVVMA, AHFENCEGVMA:
+		// SFENCEVMA -> SFENCEVMA ZERO, ZERO, ZERO
+		// SFENCEVMA rs1 -> SFENCEVMA ZERO, rs1, ZERO
+		// SFENCEVMA rs2, rs1 -> SFENCEVMA rs2, rs1, ZERO
+		switch {
+		case p.To.Type != obj.TYPE_NONE:
+			*p.From3 = p.To
+		case p.From.Type != obj.TYPE_NONE:
+			*p.From3 = p.From
+			p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		default:
+			p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+			*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+		}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+
+	case ACBOCLEAN, ACBOFLUSH, ACBOINVAL, ACBOZERO:
+		// CBOCLEAN (rs1) -> CBOCLEAN $1, rs1, ZERO
+		// The operation is selected by the immediate, so the
+		// address has no room for an offset.
+		if p.From.Type != obj.TYPE_MEM || p.From.Name != obj.NAME_NONE || p.From.Offset != 0 {
+			ctxt.Diag("%v\tcache-block operation needs a register-indirect address", p)
+		}
+		i, ok := encode(p.As)
+		if !ok {
+			panic("progedit: tried to rewrite nonexistent instruction")
+		}
+		*p.From3 = obj.Addr{Type: obj.TYPE_REG, Reg: p.From.Reg}
+		p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: i.csr}
+		p.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
+
+	case obj.AUNDEF, AECALL, AEBREAK, ASCALL, ARDCYCLE, ARDTIME, ARDINSTRET,
+		AMRET, ASRET, AWFI, AFENCETSO, AFENCEI, APAUSE:
 		if p.As == obj.AUNDEF {
 			p.As = AEBREAK
 		}
@@ -286,6 +409,13 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 			p.To.Reg = REG_ZERO
 		}
 
+	case AREV8, AAES64IM, ASHA256SIG0, ASHA256SIG1, ASHA256SUM0, ASHA256SUM1,
+		ASHA512SIG0, ASHA512SIG1, ASHA512SUM0, ASHA512SUM1:
+		// REV8 rs, rd -> REV8 $0, rs, rd
+		// The operation is selected by the rest of the immediate.
+		*p.From3 = p.From
+		p.From = obj.Addr{Type: obj.TYPE_CONST}
+
 	case ASEQZ:
 		// SEQZ rs, rd -> SLTIU $1, rs, rd
 		p.As = ASLTIU
@@ -313,9 +443,6 @@ func progedit(ctxt *obj.Link, p *obj.Prog) {
 		// This instruction expects a zero (i.e., float register 0) to
 		// be the second input operand.
 		p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_F0}
-	case AFCVTWS, AFCVTLS, AFCVTWUS, AFCVTLUS, AFCVTWD, AFCVTLD, AFCVTWUD, AFCVTLUD:
-		// Set the rounding mode in funct3 to round to zero
-		p.Scond = 1
 	}
 }
 
@@ -357,6 +484,23 @@ func InvertBranch(i obj.As) obj.As {
 	}
 }
 
+// markNoCompress sets NORVC on the instructions between NORVC and RVC
+// directives.  norvc is the state at the start of the function.
+func markNoCompress(cursym *obj.LSym, norvc bool) {
+	for p := cursym.Text; p != nil; p = p.Link {
+		switch p.As {
+		case ANORVC:
+			norvc = true
+		case ARVC:
+			norvc = false
+		default:
+			if norvc {
+				p.Mark |= NORVC
+			}
+		}
+	}
+}
+
 // containsCall reports whether the symbol contains a CALL (or equivalent)
 // instruction. Must be called after progedit.
 func containsCall(sym *obj.LSym) bool {
@@ -365,7 +509,7 @@ func containsCall(sym *obj.LSym) bool {
 		switch p.As {
 		case obj.ACALL, obj.ADUFFCOPY, obj.ADUFFZERO:
 			return true
-		case AJAL, AJALR:
+		case AJAL, AJALR, ACJALR:
 			if p.To.Type == obj.TYPE_REG && p.To.Reg == REG_RA {
 				return true
 			}
@@ -375,6 +519,99 @@ func containsCall(sym *obj.LSym) bool {
 	return false
 }
 
+// lrscMaxLen is the maximum number of instructions from an LR to its SC for
+// the sequence to be a constrained LR/SC loop, which is guaranteed to make
+// forward progress.
+const lrscMaxLen = 16
+
+// lrscAllowed reports whether p may appear between an LR and its SC:
+// whether it is a base integer computational instruction, or its compressed
+// form.  Branches and jumps are also allowed, as long as they go forward
+// and do not link.
+func lrscAllowed(p *obj.Prog) bool {
+	switch p.As {
+	case AADD, AADDI, ASUB, ALUI, ASLT, ASLTI, ASLTU, ASLTIU,
+		AAND, AANDI, AOR, AORI, AXOR, AXORI,
+		ASLL, ASLLI, ASRL, ASRLI, ASRA, ASRAI,
+		AADDW, AADDIW, ASUBW, ASLLW, ASLLIW, ASRLW, ASRLIW, ASRAW, ASRAIW,
+		ACLI, ACLUI, ACADDI, ACADDIW, ACADDI16SP, ACADDI4SPN,
+		ACSLLI, ACSRLI, ACSRAI, ACANDI, ACMV, ACADD,
+		ACAND, ACOR, ACXOR, ACSUB, ACADDW, ACSUBW, ACNOP:
+		return true
+	case AAUIPC:
+		// Unless it loads from the constant pool.
+		return p.From.Type != obj.TYPE_BRANCH
+	case ABEQ, ABNE, ABLT, ABGE, ABLTU, ABGEU, ACBEQZ, ACBNEZ, ACJ:
+		return true
+	case AJAL:
+		return p.From.Reg == REG_ZERO && p.Pcond != nil
+	}
+	return false
+}
+
+// checkLRSC finds the LR/SC sequences in cursym, diagnoses any that are not
+// constrained loops and marks their instructions LRSC.  An LR without a
+// following SC is just a load.
+//
+// Must be called once the number of instructions is final, apart from the
+// branch extension that LRSC prevents.
+func checkLRSC(ctxt *obj.Link, cursym *obj.LSym) {
+	index := make(map[*obj.Prog]int)
+	i := 0
+	for p := cursym.Text; p != nil; p = p.Link {
+		index[p] = i
+		i++
+	}
+
+	for lr := cursym.Text; lr != nil; lr = lr.Link {
+		if lr.As != ALRW && lr.As != ALRD {
+			continue
+		}
+		var sc *obj.Prog
+	Find:
+		for p := lr.Link; p != nil; p = p.Link {
+			switch p.As {
+			case ALRW, ALRD:
+				break Find
+			case ASCW, ASCD:
+				sc = p
+				break Find
+			}
+		}
+		if sc == nil {
+			continue
+		}
+
+		n := 0
+		for p := lr; p != sc.Link; p = p.Link {
+			if encodingForP(p).length == 0 {
+				continue
+			}
+			n++
+			p.Mark |= LRSC | NOCOMPRESS
+			if p == lr || p == sc {
+				continue
+			}
+			switch {
+			case p.As == AAUIPC && p.From.Type == obj.TYPE_BRANCH:
+				ctxt.Diag("%v\tconstant pool load not allowed in LR/SC sequence", p)
+				// Skip the load, which is part of the same instruction.
+				p = p.Link
+				p.Mark |= LRSC | NOCOMPRESS
+				n++
+			case !lrscAllowed(p):
+				ctxt.Diag("%v\tinstruction not allowed in LR/SC sequence", p)
+			case p.Pcond != nil && index[p.Pcond] <= index[p]:
+				ctxt.Diag("%v\tbackward branch not allowed in LR/SC sequence", p)
+			}
+		}
+		if n > lrscMaxLen {
+			ctxt.Diag("%v\tLR/SC sequence is longer than %d instructions", sc, lrscMaxLen)
+		}
+		lr = sc
+	}
+}
+
 type poolReq struct {
 	value int64
 	p     *obj.Prog
@@ -470,17 +707,26 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 		return
 	}
 
+	// A NORVC directive at the very start of the function also covers the
+	// prologue, which is inserted before it.
+	norvc := text.Link != nil && text.Link.As == ANORVC
+
 	stacksize := text.To.Offset
 
 	if stacksize < 0 {
 		text.From3.Offset |= obj.NOFRAME
 		stacksize = 0
 	}
-	// We must save RA if there is a CALL.
-	saveRA := containsCall(cursym)
+	// We must save and restore RA if there is a CALL. Leaf functions
+	// with a frame save it too, so that a traceback from a signal
+	// anywhere past the prologue finds the return PC at 0(SP), but RA
+	// itself still holds it at the RET.
+	restoreRA := containsCall(cursym)
+	saveRA := restoreRA || stacksize != 0
 	// Unless we're told not to!
 	if text.From3.Offset&obj.NOFRAME != 0 {
 		saveRA = false
+		restoreRA = false
 	}
 	if saveRA {
 		stacksize += 8
@@ -495,6 +741,21 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 		prologue = stacksplit(ctxt, prologue, stacksize) // emit split check
 	}
 
+	// Actually save RA. It is stored below SP before SP moves, so
+	// that RA is at 0(SP) whenever the pcsp table says the frame is
+	// allocated; a profiling signal between the two instructions
+	// must not find a stale return PC there.
+	if saveRA {
+		// Source register in From3, destination base register in To,
+		// destination offset in From. See MOV TYPE_REG, TYPE_MEM below
+		// for details.
+		prologue = obj.Appendp(ctxt, prologue)
+		prologue.As = ASD
+		prologue.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: REG_RA}
+		prologue.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_SP}
+		prologue.From = obj.Addr{Type: obj.TYPE_CONST, Offset: -stacksize}
+	}
+
 	// Insert stack adjustment if necessary.
 	if stacksize != 0 {
 		prologue = obj.Appendp(ctxt, prologue)
@@ -507,18 +768,6 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 		prologue.Spadj = int32(stacksize)
 	}
 
-	// Actually save RA.
-	if saveRA {
-		// Source register in From3, destination base register in To,
-		// destination offset in From. See MOV TYPE_REG, TYPE_MEM below
-		// for details.
-		prologue = obj.Appendp(ctxt, prologue)
-		prologue.As = ASD
-		prologue.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: REG_RA}
-		prologue.To = obj.Addr{Type: obj.TYPE_REG, Reg: REG_SP}
-		prologue.From = obj.Addr{Type: obj.TYPE_CONST, Offset: 0}
-	}
-
 	if cursym.Text.From3.Offset&obj.WRAPPER != 0 {
 		// if(g->panic != nil && g->panic->argp == FP) g->panic->argp = bottom-of-frame
 		//
@@ -634,6 +883,34 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 					p.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: addrtoreg(p.From)}
 					p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: p.From.Offset}
 				case obj.NAME_EXTERN, obj.NAME_STATIC:
+					if p.From.Sym != nil && p.From.Sym.Type == obj.STLSBSS {
+						// MOV tlssym(SB), R loads the offset of
+						// tlssym from the thread pointer.
+						// LUI $off_hi, R
+						// ADDIW $off_lo, R, R
+						if p.As != AMOV || p.To.Type != obj.TYPE_REG {
+							ctxt.Diag("progedit: unsupported TLS load at %v", p)
+						}
+						to := p.To
+
+						p.As = ALUI
+						// This offset isn't really encoded
+						// with either instruction. It will be
+						// extracted for a relocation later.
+						p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: p.From.Offset, Sym: p.From.Sym}
+						p.From3 = &obj.Addr{}
+						p.To = obj.Addr{Type: obj.TYPE_REG, Reg: to.Reg}
+						p.Mark |= NEED_TLS_LE_RELOC | NOCOMPRESS
+						p = obj.Appendp(ctxt, p)
+
+						p.As = AADDIW
+						p.From = obj.Addr{Type: obj.TYPE_CONST}
+						p.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: to.Reg}
+						p.Mark |= NOCOMPRESS
+						p.To = obj.Addr{Type: obj.TYPE_REG, Reg: to.Reg}
+						break
+					}
+
 					// AUIPC $off_hi, R
 					// L $off_lo, R
 					as := p.As
@@ -789,7 +1066,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 			switch p.To.Type {
 			case obj.TYPE_MEM:
 				switch p.To.Name {
-				case obj.NAME_EXTERN:
+				case obj.NAME_EXTERN, obj.NAME_STATIC:
 					// JMP to symbol.
 					jalrToSym(ctxt, p, REG_ZERO)
 				}
@@ -802,7 +1079,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 				linkreg = REG_RA
 			}
 
-			if saveRA {
+			if restoreRA {
 				// Restore RA.
 				p.As = ALD
 				p.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: REG_SP}
@@ -899,6 +1176,26 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 			p.From3 = q.From3
 			p.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_TMP}
 
+		// Explicitly compressed loads and stores are never split, so
+		// just move the base register out of the memory operand.
+		case ACLWSP, ACLDSP, ACFLDSP, ACLW, ACLD, ACFLD:
+			// CLW c(Rs), Rd -> CLW $c, Rs, Rd
+			if p.From.Type != obj.TYPE_MEM || p.From.Sym != nil {
+				ctxt.Diag("progedit: unsupported load at %v", p)
+				break
+			}
+			p.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: addrtoreg(p.From)}
+			p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: p.From.Offset}
+		case ACSWSP, ACSDSP, ACFSDSP, ACSW, ACSD, ACFSD:
+			// CSW Rs, c(Rd) -> CSW $c, Rs, Rd
+			if p.To.Type != obj.TYPE_MEM || p.To.Sym != nil {
+				ctxt.Diag("progedit: unsupported store at %v", p)
+				break
+			}
+			*p.From3 = p.From
+			p.From = obj.Addr{Type: obj.TYPE_CONST, Offset: p.To.Offset}
+			p.To = obj.Addr{Type: obj.TYPE_REG, Reg: addrtoreg(p.To)}
+
 		// <load> $imm, FROM3, TO (load $imm+(FROM3), TO)
 		// <store> $imm, FROM3, TO (store $imm+(TO), FROM3)
 		case ALD, ALB, ALH, ALW, ALBU, ALHU, ALWU,
@@ -942,6 +1239,9 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 		}
 	}
 
+	markNoCompress(cursym, norvc)
+	checkLRSC(ctxt, cursym)
+
 	// Compute instruction addresses.  Once we do that, we need to check for
 	// overextended jumps and branches.  Within each iteration, Pc differences
 	// are always lower bounds (since the program gets monotonically longer,
@@ -956,6 +1256,11 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 				if p.To.Type != obj.TYPE_BRANCH {
 					panic("assemble: instruction with branch-like opcode lacks destination")
 				}
+				if p.Mark&LRSC != 0 {
+					// Never extended inside an LR/SC sequence;
+					// validation reports one that is too long.
+					break
+				}
 				offset := p.Pcond.Pc - p.Pc
 				if offset < -4096 || 4096 <= offset {
 					// Branch is long.  Replace it with a jump.
@@ -964,6 +1269,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 					jmp.From = obj.Addr{Type: obj.TYPE_REG, Reg: REG_ZERO}
 					jmp.To = obj.Addr{Type: obj.TYPE_BRANCH}
 					jmp.Pcond = p.Pcond
+					jmp.Mark |= p.Mark & NORVC
 
 					p.As = InvertBranch(p.As)
 					p.Pcond = jmp.Link
@@ -976,6 +1282,10 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 					rescan = true
 				}
 			case AJAL:
+				if p.Mark&LRSC != 0 {
+					// As for branches.
+					break
+				}
 				if p.Pcond != nil {
 					// Internal jump.  Rewrite if it doesn't fit right now.
 					offset := p.Pcond.Pc - p.Pc
@@ -986,6 +1296,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 						jmp.From = obj.Addr{Type: obj.TYPE_CONST, Offset: 0}
 						jmp.To = p.From
 						jmp.From3 = &obj.Addr{Type: obj.TYPE_REG, Reg: REG_TMP}
+						jmp.Mark |= p.Mark & NORVC
 						// Assuming TMP is not live across J instructions, since it's reserved by SSA that should be OK
 
 						p.As = AAUIPC
@@ -1074,7 +1385,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 	// instructions will break everything--don't do it!
 	for p := cursym.Text; p != nil; p = p.Link {
 		switch p.As {
-		case ABEQ, ABNE, ABLT, ABGE, ABLTU, ABGEU, AJAL:
+		case ABEQ, ABNE, ABLT, ABGE, ABLTU, ABGEU, AJAL, ACJ, ACBEQZ, ACBNEZ:
 			if p.To.Type == obj.TYPE_BRANCH {
 				p.To.Type = obj.TYPE_CONST
 				p.To.Offset = p.Pcond.Pc - p.Pc
@@ -1094,6 +1405,7 @@ func preprocess(ctxt *obj.Link, cursym *obj.LSym) {
 	// Validate all instructions. This provides nice error messages.
 	for p := cursym.Text; p != nil; p = p.Link {
 		encodingForP(p).validate(p)
+		validateRoundingMode(p)
 	}
 }
 
@@ -1407,6 +1719,55 @@ func validateRFF(p *obj.Prog) {
 	wantFloatReg(p, "to", &p.To)
 }
 
+// hasRoundingMode reports whether the instruction has an rm field in place
+// of funct3.
+func hasRoundingMode(as obj.As) bool {
+	switch as {
+	case AFADDS, AFSUBS, AFMULS, AFDIVS, AFSQRTS,
+		AFCVTWS, AFCVTLS, AFCVTSW, AFCVTSL, AFCVTWUS, AFCVTLUS, AFCVTSWU, AFCVTSLU,
+		AFADDD, AFSUBD, AFMULD, AFDIVD, AFSQRTD,
+		AFCVTWD, AFCVTLD, AFCVTDW, AFCVTDL, AFCVTWUD, AFCVTLUD, AFCVTDWU, AFCVTDLU,
+		AFCVTSD, AFCVTDS:
+		return true
+	}
+	return false
+}
+
+// roundingMode returns the rm field for the rounding mode in Prog.Scond.
+func roundingMode(p *obj.Prog) uint32 {
+	switch p.Scond {
+	case RM_NONE:
+		switch p.As {
+		case AFCVTWS, AFCVTLS, AFCVTWUS, AFCVTLUS, AFCVTWD, AFCVTLD, AFCVTWUD, AFCVTLUD:
+			// Float to integer conversions truncate, as in Go.
+			return 1
+		}
+		return 0
+	case RM_RNE:
+		return 0
+	case RM_RTZ, RM_RDN, RM_RUP, RM_RMM:
+		return uint32(p.Scond - RM_RNE)
+	case RM_DYN:
+		return 7
+	}
+	panic(fmt.Sprintf("roundingMode: bad rounding mode %d", p.Scond))
+}
+
+// validateRoundingMode checks that a rounding mode is only given to
+// instructions which have one.
+func validateRoundingMode(p *obj.Prog) {
+	if p.Scond == RM_NONE {
+		return
+	}
+	if !hasRoundingMode(p.As) {
+		p.Ctxt.Diag("%v\tinstruction does not take a rounding mode", p)
+		return
+	}
+	if p.Scond > RM_DYN {
+		p.Ctxt.Diag("%v\tinvalid rounding mode %d", p, p.Scond)
+	}
+}
+
 func encodeR(p *obj.Prog, rs1 uint32, rs2 uint32, rd uint32) uint32 {
 	i, ok := encode(p.As)
 	if !ok {
@@ -1415,10 +1776,11 @@ func encodeR(p *obj.Prog, rs1 uint32, rs2 uint32, rd uint32) uint32 {
 	if i.rs2 != 0 && rs2 != 0 {
 		panic("encodeR: instruction uses rs2, but rs2 was nonzero")
 	}
-
-	// Using Scond for the floating-point rounding mode override
-	// TODO(sorear) is there a more appropriate way to handle opcode extension bits like this?
-	return i.funct7<<25 | i.rs2<<20 | rs2<<20 | rs1<<15 | i.funct3<<12 | uint32(p.Scond)<<12 | rd<<7 | i.opcode
+	funct3 := i.funct3
+	if hasRoundingMode(p.As) {
+		funct3 = roundingMode(p)
+	}
+	return i.funct7<<25 | i.rs2<<20 | rs2<<20 | rs1<<15 | funct3<<12 | rd<<7 | i.opcode
 }
 
 func encodeRIII(p *obj.Prog) uint32 {
@@ -1445,12 +1807,37 @@ func encodeRFF(p *obj.Prog) uint32 {
 	return encodeR(p, regf(p.From), 0, regf(p.To))
 }
 
+// encodeA encodes an LR or SC.  Both set aq and rl, making them sequentially
+// consistent as the runtime's atomics require.
+func encodeA(p *obj.Prog) uint32 {
+	return encodeRIII(p) | 3<<25
+}
+
 func validateII(p *obj.Prog) {
 	wantImm(p, "from", p.From, 12)
 	wantIntReg(p, "from3", p.From3)
 	wantIntReg(p, "to", &p.To)
 }
 
+// validateIIField is validateII for instructions whose immediate is a
+// small field next to bits that select the operation: rotate amounts
+// and the AES64KS1I round number.
+func validateIIField(p *obj.Prog) {
+	validateII(p)
+	var max int64
+	switch p.As {
+	case ARORI:
+		max = 63
+	case ARORIW:
+		max = 31
+	case AAES64KS1I:
+		max = 10
+	}
+	if p.From.Type == obj.TYPE_CONST && (p.From.Offset < 0 || p.From.Offset > max) {
+		p.Ctxt.Diag("%v	immediate in from position must be in the range [0, %d] but got %d", p, max, p.From.Offset)
+	}
+}
+
 func validateIF(p *obj.Prog) {
 	wantImm(p, "from", p.From, 12)
 	wantIntReg(p, "from3", p.From3)
@@ -1547,7 +1934,7 @@ func encodeSB(p *obj.Prog) uint32 {
 }
 
 func validateU(p *obj.Prog) {
-	if p.As == AAUIPC && p.Mark&(NEED_PCREL_ITYPE_RELOC|NEED_PCREL_STYPE_RELOC) != 0 {
+	if p.Mark&(NEED_PCREL_ITYPE_RELOC|NEED_PCREL_STYPE_RELOC|NEED_TLS_LE_RELOC) != 0 {
 		// TODO(sorear): Hack.  The Offset is being used here to temporarily
 		// store the relocation addend, not as an actual offset to assemble,
 		// so it's OK for it to be out of range.  Is there a more valid way
@@ -1651,7 +2038,7 @@ func encodeRaw(p *obj.Prog) uint32 {
 type encoding struct {
 	encode   func(*obj.Prog) uint32 // encode returns the machine code for a Prog
 	validate func(*obj.Prog)        // validate validates a Prog, calling ctxt.Diag for any issues
-	length   int64                  // length of encoded instruction; 0 for pseudo-ops, 4 otherwise
+	length   int64                  // length of encoded instruction; 0 for pseudo-ops, 2 for compressed instructions, 4 otherwise
 }
 
 var (
@@ -1685,6 +2072,17 @@ var (
 
 	rawEncoding = encoding{encode: encodeRaw, validate: validateRaw, length: 4}
 
+	// aEncoding is used for LR and SC, which are R-type instructions with
+	// the aq and rl bits set.
+	aEncoding = encoding{encode: encodeA, validate: validateRIII, length: 4}
+
+	// iIFieldEncoding is used for I-type instructions whose immediate
+	// shares its bits with the opcode, such as RORI.
+	iIFieldEncoding = encoding{encode: encodeII, validate: validateIIField, length: 4}
+
+	// cEncoding is used for explicitly compressed instructions.
+	cEncoding = encoding{encode: encodeCompressed, validate: validateCompressed, length: 2}
+
 	// pseudoOpEncoding panics if encoding is attempted, but does no validation.
 	pseudoOpEncoding = encoding{encode: nil, validate: func(*obj.Prog) {}, length: 0}
 
@@ -1708,6 +2106,12 @@ var encodingForAs = [...]encoding{
 	ABGE & obj.AMask:  sbEncoding,
 	ABGEU & obj.AMask: sbEncoding,
 
+	// 2.7: Memory Model
+	AFENCE & obj.AMask:    iIEncoding,
+	AFENCETSO & obj.AMask: iIEncoding,
+	AFENCEI & obj.AMask:   iIEncoding,
+	APAUSE & obj.AMask:    iIEncoding,
+
 	// 2.9: Environment Call and Breakpoints
 	AECALL & obj.AMask:  iIEncoding,
 	AEBREAK & obj.AMask: iIEncoding,
@@ -1771,6 +2175,12 @@ var encodingForAs = [...]encoding{
 	AREMW & obj.AMask:   rIIIEncoding,
 	AREMUW & obj.AMask:  rIIIEncoding,
 
+	// 6.2: Load-Reserved/Store-Conditional Instructions
+	ALRW & obj.AMask: aEncoding,
+	ALRD & obj.AMask: aEncoding,
+	ASCW & obj.AMask: aEncoding,
+	ASCD & obj.AMask: aEncoding,
+
 	// 7.5: Single-Precision Load and Store Instructions
 	AFLW & obj.AMask: iFEncoding,
 	AFSW & obj.AMask: sFEncoding,
@@ -1781,12 +2191,18 @@ var encodingForAs = [...]encoding{
 	AFMULS & obj.AMask:  rFFFEncoding,
 	AFDIVS & obj.AMask:  rFFFEncoding,
 	AFSQRTS & obj.AMask: rFFFEncoding,
+	AFMINS & obj.AMask:  rFFFEncoding,
+	AFMAXS & obj.AMask:  rFFFEncoding,
 
 	// 7.7: Single-Precision Floating-Point Conversion and Move Instructions
 	AFCVTWS & obj.AMask:  rFIEncoding,
 	AFCVTLS & obj.AMask:  rFIEncoding,
 	AFCVTSW & obj.AMask:  rIFEncoding,
 	AFCVTSL & obj.AMask:  rIFEncoding,
+	AFCVTWUS & obj.AMask: rFIEncoding,
+	AFCVTLUS & obj.AMask: rFIEncoding,
+	AFCVTSWU & obj.AMask: rIFEncoding,
+	AFCVTSLU & obj.AMask: rIFEncoding,
 	AFSGNJS & obj.AMask:  rFFFEncoding,
 	AFSGNJNS & obj.AMask: rFFFEncoding,
 	AFSGNJXS & obj.AMask: rFFFEncoding,
@@ -1797,6 +2213,9 @@ var encodingForAs = [...]encoding{
 	AFLTS & obj.AMask: rFFIEncoding,
 	AFLES & obj.AMask: rFFIEncoding,
 
+	// 7.9: Single-Precision Floating-Point Classify Instruction
+	AFCLASSS & obj.AMask: rFIEncoding,
+
 	// 8.2: Double-Precision Load and Store Instructions
 	AFLD & obj.AMask: iFEncoding,
 	AFSD & obj.AMask: sFEncoding,
@@ -1807,12 +2226,18 @@ var encodingForAs = [...]encoding{
 	AFMULD & obj.AMask:  rFFFEncoding,
 	AFDIVD & obj.AMask:  rFFFEncoding,
 	AFSQRTD & obj.AMask: rFFFEncoding,
+	AFMIND & obj.AMask:  rFFFEncoding,
+	AFMAXD & obj.AMask:  rFFFEncoding,
 
 	// 8.4: Double-Precision Floating-Point Conversion and Move Instructions
 	AFCVTWD & obj.AMask:  rFIEncoding,
 	AFCVTLD & obj.AMask:  rFIEncoding,
 	AFCVTDW & obj.AMask:  rIFEncoding,
 	AFCVTDL & obj.AMask:  rIFEncoding,
+	AFCVTWUD & obj.AMask: rFIEncoding,
+	AFCVTLUD & obj.AMask: rFIEncoding,
+	AFCVTDWU & obj.AMask: rIFEncoding,
+	AFCVTDLU & obj.AMask: rIFEncoding,
 	AFCVTSD & obj.AMask:  rFFEncoding,
 	AFCVTDS & obj.AMask:  rFFEncoding,
 	AFSGNJD & obj.AMask:  rFFFEncoding,
@@ -1825,14 +2250,116 @@ var encodingForAs = [...]encoding{
 	AFLTD & obj.AMask: rFFIEncoding,
 	AFLED & obj.AMask: rFFIEncoding,
 
+	// 8.6: Double-Precision Floating-Point Classify Instruction
+	AFCLASSD & obj.AMask: rFIEncoding,
+
+	// 12.3: Compressed Load and Store Instructions
+	ACLWSP & obj.AMask:  cEncoding,
+	ACLDSP & obj.AMask:  cEncoding,
+	ACFLDSP & obj.AMask: cEncoding,
+	ACSWSP & obj.AMask:  cEncoding,
+	ACSDSP & obj.AMask:  cEncoding,
+	ACFSDSP & obj.AMask: cEncoding,
+	ACLW & obj.AMask:    cEncoding,
+	ACLD & obj.AMask:    cEncoding,
+	ACFLD & obj.AMask:   cEncoding,
+	ACSW & obj.AMask:    cEncoding,
+	ACSD & obj.AMask:    cEncoding,
+	ACFSD & obj.AMask:   cEncoding,
+
+	// 12.4: Compressed Control Transfer Instructions
+	ACJ & obj.AMask:    cEncoding,
+	ACJR & obj.AMask:   cEncoding,
+	ACJALR & obj.AMask: cEncoding,
+	ACBEQZ & obj.AMask: cEncoding,
+	ACBNEZ & obj.AMask: cEncoding,
+
+	// 12.5: Compressed Integer Computational Instructions
+	ACLI & obj.AMask:       cEncoding,
+	ACLUI & obj.AMask:      cEncoding,
+	ACADDI & obj.AMask:     cEncoding,
+	ACADDIW & obj.AMask:    cEncoding,
+	ACADDI16SP & obj.AMask: cEncoding,
+	ACADDI4SPN & obj.AMask: cEncoding,
+	ACSLLI & obj.AMask:     cEncoding,
+	ACSRLI & obj.AMask:     cEncoding,
+	ACSRAI & obj.AMask:     cEncoding,
+	ACANDI & obj.AMask:     cEncoding,
+	ACMV & obj.AMask:       cEncoding,
+	ACADD & obj.AMask:      cEncoding,
+	ACAND & obj.AMask:      cEncoding,
+	ACOR & obj.AMask:       cEncoding,
+	ACXOR & obj.AMask:      cEncoding,
+	ACSUB & obj.AMask:      cEncoding,
+	ACADDW & obj.AMask:     cEncoding,
+	ACSUBW & obj.AMask:     cEncoding,
+	ACNOP & obj.AMask:      cEncoding,
+
+	// 12.6: Compressed Breakpoint Instruction
+	ACEBREAK & obj.AMask: cEncoding,
+
+	// 3.3: Machine-Mode Privileged Instructions
+	AMRET & obj.AMask: iIEncoding,
+	ASRET & obj.AMask: iIEncoding,
+	AWFI & obj.AMask:  iIEncoding,
+
+	// 4.2.1: Supervisor Memory-Management Fence Instruction
+	ASFENCEVMA & obj.AMask: rIIIEncoding,
+
+	// 8.3.2: Hypervisor Memory-Management Fence Instructions
+	AHFENCEVVMA & obj.AMask: rIIIEncoding,
+	AHFENCEGVMA & obj.AMask: rIIIEncoding,
+
+	// Cache-Block Operations
+	ACBOCLEAN & obj.AMask: iIEncoding,
+	ACBOFLUSH & obj.AMask: iIEncoding,
+	ACBOINVAL & obj.AMask: iIEncoding,
+	ACBOZERO & obj.AMask:  iIEncoding,
+
+	// Bit-Manipulation (Zbb, Zbkb)
+	AANDN & obj.AMask:  rIIIEncoding,
+	AORN & obj.AMask:   rIIIEncoding,
+	AXNOR & obj.AMask:  rIIIEncoding,
+	AROL & obj.AMask:   rIIIEncoding,
+	AROR & obj.AMask:   rIIIEncoding,
+	ARORI & obj.AMask:  iIFieldEncoding,
+	AROLW & obj.AMask:  rIIIEncoding,
+	ARORW & obj.AMask:  rIIIEncoding,
+	ARORIW & obj.AMask: iIFieldEncoding,
+	AREV8 & obj.AMask:  iIEncoding,
+
+	// Carry-less Multiplication (Zbc)
+	ACLMUL & obj.AMask:  rIIIEncoding,
+	ACLMULH & obj.AMask: rIIIEncoding,
+	ACLMULR & obj.AMask: rIIIEncoding,
+
+	// Scalar Cryptography (Zknd, Zkne, Zknh)
+	AAES64DS & obj.AMask:    rIIIEncoding,
+	AAES64DSM & obj.AMask:   rIIIEncoding,
+	AAES64ES & obj.AMask:    rIIIEncoding,
+	AAES64ESM & obj.AMask:   rIIIEncoding,
+	AAES64IM & obj.AMask:    iIEncoding,
+	AAES64KS1I & obj.AMask:  iIFieldEncoding,
+	AAES64KS2 & obj.AMask:   rIIIEncoding,
+	ASHA256SIG0 & obj.AMask: iIEncoding,
+	ASHA256SIG1 & obj.AMask: iIEncoding,
+	ASHA256SUM0 & obj.AMask: iIEncoding,
+	ASHA256SUM1 & obj.AMask: iIEncoding,
+	ASHA512SIG0 & obj.AMask: iIEncoding,
+	ASHA512SIG1 & obj.AMask: iIEncoding,
+	ASHA512SUM0 & obj.AMask: iIEncoding,
+	ASHA512SUM1 & obj.AMask: iIEncoding,
+
 	// Escape hatch
 	AWORD & obj.AMask: rawEncoding,
 
 	// Pseudo-operations
-	obj.AFUNCDATA: pseudoOpEncoding,
-	obj.APCDATA:   pseudoOpEncoding,
-	obj.ATEXT:     pseudoOpEncoding,
-	obj.ANOP:      pseudoOpEncoding,
+	obj.AFUNCDATA:      pseudoOpEncoding,
+	obj.APCDATA:        pseudoOpEncoding,
+	obj.ATEXT:          pseudoOpEncoding,
+	obj.ANOP:           pseudoOpEncoding,
+	ANORVC & obj.AMask: pseudoOpEncoding,
+	ARVC & obj.AMask:   pseudoOpEncoding,
 }
 
 // encodingForP returns the encoding (encode+validate funcs) for a Prog.
@@ -1854,7 +2381,10 @@ func encodingForP(p *obj.Prog) encoding {
 	return enc
 }
 
-func compressLoadStore(p *obj.Prog, store bool, float bool, typecode int, size int) uint16 {
+// compressLoadStore compresses a load or store.  If sp is set, only the
+// SP-relative form is considered; otherwise only the form using two of the
+// registers x8-x15 is.
+func compressLoadStore(p *obj.Prog, store bool, float bool, typecode int, size int, sp bool) uint16 {
 	imm := int(p.From.Offset)
 
 	if imm < 0 || imm&(size-1) != 0 {
@@ -1877,8 +2407,8 @@ func compressLoadStore(p *obj.Prog, store bool, float bool, typecode int, size i
 		datum = int(regi(datumv))
 	}
 
-	if base == REG_SP-REG_X0 {
-		if imm >= size*64 || datum == 0 && !store && !float {
+	if sp {
+		if base != REG_SP-REG_X0 || imm >= size*64 || datum == 0 && !store && !float {
 			return 0
 		}
 		// fold immediate 5:0
@@ -1888,208 +2418,461 @@ func compressLoadStore(p *obj.Prog, store bool, float bool, typecode int, size i
 		} else {
 			return uint16(typecode<<13 | (immf&32)<<7 | datum<<7 | (immf&31)<<2 | 2)
 		}
-	} else if base >= 8 && base <= 15 && datum >= 8 && datum <= 15 {
-		if imm >= size*32 {
-			return 0
-		}
-		// fold immediate into 5:1, shr 1
-		immf := (imm&63 | imm>>5) >> 1
-		if store {
-			return uint16(1<<15 | typecode<<13 | (immf>>2)<<10 | (base&7)<<7 | (immf&3)<<5 | (datum&7)<<2 | 0)
-		} else {
-			return uint16(0<<15 | typecode<<13 | (immf>>2)<<10 | (base&7)<<7 | (immf&3)<<5 | (datum&7)<<2 | 0)
-		}
+	}
+	if !isCReg(uint32(base)) || !isCReg(uint32(datum)) || imm >= size*32 {
+		return 0
+	}
+	// fold immediate into 5:1, shr 1
+	immf := (imm&63 | imm>>5) >> 1
+	if store {
+		return uint16(1<<15 | typecode<<13 | (immf>>2)<<10 | (base&7)<<7 | (immf&3)<<5 | (datum&7)<<2 | 0)
 	} else {
+		return uint16(0<<15 | typecode<<13 | (immf>>2)<<10 | (base&7)<<7 | (immf&3)<<5 | (datum&7)<<2 | 0)
+	}
+}
+
+// isCReg reports whether register number r is one of x8-x15 (or f8-f15),
+// the only registers most compressed instructions can name.
+func isCReg(r uint32) bool {
+	return r >= 8 && r <= 15
+}
+
+// A compressor returns the 16-bit encoding of p in one particular compressed
+// form, or 0 if p cannot be expressed in that form.  0 is a valid but
+// permanently undefined encoding.
+//
+// If sizing is set, branch offsets have not been resolved yet and only the
+// length of the result matters.
+type compressor func(p *obj.Prog, sizing bool) uint16
+
+// 12.3: Load and Store Instructions
+
+func compressFLDSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, true, 1, 8, true)
+}
+func compressLWSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, false, 2, 4, true)
+}
+func compressLDSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, false, 3, 8, true)
+}
+func compressFSDSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, true, 1, 8, true)
+}
+func compressSWSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, false, 2, 4, true)
+}
+func compressSDSP(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, false, 3, 8, true)
+}
+func compressFLD(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, true, 1, 8, false)
+}
+func compressLW(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, false, 2, 4, false)
+}
+func compressLD(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, false, false, 3, 8, false)
+}
+func compressFSD(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, true, 1, 8, false)
+}
+func compressSW(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, false, 2, 4, false)
+}
+func compressSD(p *obj.Prog, sizing bool) uint16 {
+	return compressLoadStore(p, true, false, 3, 8, false)
+}
+
+// 12.4: Control Transfer Instructions
+//
+// Branches _fail_ if overextended because that needs to be handled in the
+// branch extension pass (to avoid lengths changing when offsets are set).
+
+func compressJ(p *obj.Prog, sizing bool) uint16 {
+	// not if it will be relocated
+	if p.To.Sym != nil || regi(p.From) != 0 {
 		return 0
 	}
+	if sizing && p.To.Type == obj.TYPE_BRANCH {
+		// no offset yet, don't crash
+		return 0x9001
+	}
+	if !immFits(p.To.Offset, 12) {
+		return 0
+	}
+	// offset[11|4|9:8|10|6|7|3:1|5] << 2
+	o := uint16(immi(p.To, 12))
+	return uint16(0xA001 | ((o>>11)&1)<<12 | ((o>>4)&1)<<11 | ((o>>8)&3)<<9 | ((o>>10)&1)<<8 | ((o>>6)&1)<<7 | ((o>>7)&1)<<6 | ((o>>1)&7)<<3 | ((o>>5)&1)<<2)
 }
 
-// some instructions have 16-bit compressed encodings; they're irregular, few in number, and not in machine readable form so just list them
-// returns 0 if there is no compressed encoding, which a valid but permanently undefined encoding
-func compress(p *obj.Prog, sizing bool) uint16 {
-	if p.Mark&NOCOMPRESS != 0 || !GORISCVRVC {
+func compressJR(p *obj.Prog, sizing bool) uint16 {
+	// C.JR // 100 0 rs1!=0 0 10
+	from := regi(*p.From3)
+	if p.From.Offset == 0 && from != 0 && regi(p.To) == 0 {
+		return uint16(0x8002 | from<<7)
+	}
+	return 0
+}
+
+func compressJALR(p *obj.Prog, sizing bool) uint16 {
+	// C.JALR // 100 1 rs1!=0 0 10
+	from := regi(*p.From3)
+	if p.From.Offset == 0 && from != 0 && regi(p.To) == uint32(REG_RA-REG_X0) {
+		return uint16(0x9002 | from<<7)
+	}
+	return 0
+}
+
+func compressBranch(p *obj.Prog, sizing bool) uint16 {
+	// C.BEQZ // 110 offset[8|4:3] rs1' offset[7:6|2:1|5] 01
+	// C.BNEZ // 111 offset[8|4:3] rs1' offset[7:6|2:1|5] 01
+	rs2 := regval(p.Reg, REG_X0, REG_X31)
+	rs1 := regi(p.From)
+	if !isCReg(rs1) || rs2 != 0 {
+		return 0
+	}
+	if sizing && p.To.Type == obj.TYPE_BRANCH {
+		// no offset yet, don't crash
+		return 0x9001
+	}
+	if !immFits(p.To.Offset, 9) {
 		return 0
 	}
+	opc := uint16(0xC001)
+	if p.As == ABNE {
+		opc = 0xE001
+	}
+	o := uint16(immi(p.To, 9))
+	return uint16(opc | ((o>>8)&1)<<12 | ((o>>3)&3)<<10 | uint16(rs1&7)<<7 | ((o>>6)&3)<<5 | ((o>>1)&3)<<3 | ((o>>5)&1)<<2)
+}
+
+// 12.5: Integer Computational Instructions
+//
+// Integer Constant-Generation Instructions
+
+func compressLI(p *obj.Prog, sizing bool) uint16 {
+	// C.LI // 010 imm[5] rd!=0 imm[4:0] 01
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rs1 == 0 && rd != 0 && immFits(off, 6) {
+		o := uint16(off)
+		return uint16(0x4001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+	}
+	return 0
+}
+
+func compressLUI(p *obj.Prog, sizing bool) uint16 {
+	// C.LUI // 011 nzimm[17] rd!={0,2} nzimm[16:12] 01
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rd != 0 && rd != uint32(REG_SP-REG_X0) && off != 0 && immFits(off, 6) {
+		o := uint16(off)
+		return uint16(0x6001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+	}
+	return 0
+}
+
+// Integer Register-Immediate Operations
+
+func compressADDI(p *obj.Prog, sizing bool) uint16 {
+	// C.ADDI // 000 nzimm[5] rs1/rd!=0 nzimm[4:0] 01
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rs1 == rd && rs1 != 0 && off != 0 && immFits(off, 6) {
+		o := uint16(off)
+		return uint16(0x0001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+	}
+	return 0
+}
+
+func compressADDIW(p *obj.Prog, sizing bool) uint16 {
+	// C.ADDIW // 001 imm[5] rs1/rd!=0 imm[4:0] 01
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rs1 == rd && rs1 != 0 && immFits(off, 6) {
+		o := uint16(off)
+		return uint16(0x2001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+	}
+	return 0
+}
+
+func compressADDI16SP(p *obj.Prog, sizing bool) uint16 {
+	// C.ADDI16SP // 011 nzimm[9] 2 nzimm[4|6|8:7|5] 01
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rd == uint32(REG_SP-REG_X0) && rs1 == rd && off != 0 && off&15 == 0 && immFits(off, 10) {
+		o := uint16(off)
+		return uint16(0x6101 | ((o>>9)&1)<<12 | ((o>>4)&1)<<6 | ((o>>6)&1)<<5 | ((o>>7)&3)<<3 | ((o>>5)&1)<<2)
+	}
+	return 0
+}
+
+func compressADDI4SPN(p *obj.Prog, sizing bool) uint16 {
+	// C.ADDI4SPN // 000 nzimm[5:4|9:6|2|3] rd' 00
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if isCReg(rd) && rs1 == uint32(REG_SP-REG_X0) && off > 0 && off < 1024 && off&3 == 0 {
+		o := uint16(off)
+		return uint16(0x0000 | ((o>>4)&3)<<11 | ((o>>6)&15)<<7 | ((o>>2)&1)<<6 | ((o>>3)&1)<<5 | uint16(rd&7)<<2)
+	}
+	return 0
+}
+
+func compressSLLI(p *obj.Prog, sizing bool) uint16 {
+	// C.SLLI // 000 nzimm[5] rd!=0 nzimm[4:0] 10
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	off := p.From.Offset
+	if rs1 == rd && rs1 != 0 && off > 0 && off < 64 {
+		o := uint16(off)
+		return uint16(0x0002 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+	}
+	return 0
+}
+
+func compressShiftAndI(p *obj.Prog, sizing bool) uint16 {
+	// C.SRLI // 100 nzimm[5] 00 rs1'/rd' nzimm[4:0] 01
+	// C.SRAI // 100 nzimm[5] 01 rs1'/rd' nzimm[4:0] 01
+	// C.ANDI // 100 imm[5] 10 rs1'/rd' imm[4:0] 01
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
 	off := p.From.Offset
+	if rs1 != rd || !isCReg(rd) {
+		return 0
+	}
+	var opc uint16
 	switch p.As {
-	// 14.3 Load and Store Instructions
-	case AFLD:
-		return compressLoadStore(p, false, true, 1, 8)
-	case ALW:
-		return compressLoadStore(p, false, false, 2, 4)
-	case ALD:
-		return compressLoadStore(p, false, false, 3, 8)
-	case AFSD:
-		return compressLoadStore(p, true, true, 1, 8)
-	case ASW:
-		return compressLoadStore(p, true, false, 2, 4)
-	case ASD:
-		return compressLoadStore(p, true, false, 3, 8)
-	// 14.4 Control Transfer Instructions
-	case AJAL:
-		// not if it will be relocated
-		if p.To.Sym != nil {
+	case ASRLI, ASRAI:
+		if off <= 0 || off >= 64 {
 			return 0
 		}
-		lr := regi(p.From)
-		// Branches _fail_ if overextended because that needs to be handled in the branch extension pass (to avoid lengths changing when offsets are set)
-		if lr == 0 /* J */ {
-			if sizing && p.To.Type == obj.TYPE_BRANCH {
-				// no offset yet, don't crash
-				return 0x9001
-			}
-			// offset[11|4|9:8|10|6|7|3:1|5] << 2
-			o := uint16(immi(p.To, 12))
-			return uint16(0xA001 | ((o>>11)&1)<<12 | ((o>>4)&1)<<11 | ((o>>8)&3)<<9 | ((o>>10)&1)<<8 | ((o>>6)&1)<<7 | ((o>>7)&1)<<6 | ((o>>1)&7)<<3 | ((o>>5)&1)<<2)
-		}
-	case AJALR:
-		lr := regi(p.To)
-		from := regi(*p.From3)
-		if p.From.Offset == 0 && from != 0 /* REG_ZERO */ && (lr == 0 /* JR */ || lr == 1 /* JALR */) {
-			return uint16(0x8002 | lr<<12 | from<<7)
-		}
-	case ABEQ, ABNE:
-		rs2 := regval(p.Reg, REG_X0, REG_X31)
-		rs1 := regi(p.From)
-		// Branches _fail_ if overextended because that needs to be handled in the branch extension pass (to avoid lengths changing when offsets are set)
-		if rs1 >= 8 && rs1 <= 15 && rs2 == 0 {
-			// offset[8|4:3] rs1' offset[7:6|2:1|5]
-			opc := uint16(0xC001)
-			if sizing && p.To.Type == obj.TYPE_BRANCH {
-				// no offset yet, don't crash
-				return 0x9001
-			}
-			o := uint16(immi(p.To, 9))
-			if p.As == ABNE {
-				opc = 0xE001
-			}
-			return uint16(opc | ((o>>8)&1)<<12 | ((o>>3)&3)<<10 | uint16(rs1&7)<<7 | ((o>>6)&3)<<5 | ((o>>1)&3)<<3 | ((o>>5)&1)<<2)
-		}
-	// 14.5 Integer Computational Instructions
-	// Integer Constant-Generation Instructions
-	case AADDI:
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rd == uint32(REG_SP-REG_X0) && rs1 == rd && off&15 == 0 && immFits(off, 10) {
-			// C.ADDI16SP // 011 nzimm[9] 2 nzimm[4|6|8:7|5] 01
-			o := uint16(p.From.Offset)
-			return uint16(0x6101 | ((o>>9)&1)<<12 | ((o>>4)&1)<<6 | ((o>>6)&1)<<5 | ((o>>7)&3)<<3 | ((o>>5)&1)<<2)
-		}
-		if rd >= 8 && rd <= 15 && rs1 == uint32(REG_SP-REG_X0) && off > 0 && off < 1024 && off&3 == 0 {
-			// C.ADDI4SPN // 000 nzimm[5:4|9:6|2|3] rd' 00a
-			o := uint16(p.From.Offset)
-			return uint16(0x0000 | ((o>>4)&3)<<11 | ((o>>6)&15)<<7 | ((o>>2)&1)<<6 | ((o>>3)&1)<<5 | uint16(rd&7)<<2)
-		}
-		if rs1 == rd && rs1 != 0 && off != 0 && immFits(off, 6) {
-			// C.ADDI // 000 nzimm[5] rs1/rd!=0 nzimm[4:0] 01
-			o := uint16(p.From.Offset)
-			return uint16(0x0001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
-		}
-		if rs1 == 0 && rd == 0 && off == 0 {
-			// C.NOP // 000 0 0 0 01
-			return uint16(0x0001)
-		}
-		if rs1 == 0 && rd != 0 && immFits(off, 6) {
-			// C.LI // 010 imm[5] rs1/rd!=0 imm[4:0] 01
-			o := uint16(p.From.Offset)
-			return uint16(0x4001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
-		}
-		if off == 0 && rs1 != 0 && rd != 0 {
-			// C.MV // 100 0 rd!=0 rs2!=0 10
-			return uint16(0x8002 | rd<<7 | rs1<<2)
-		}
-	case ALUI:
-		rd := regi(p.To)
-		if rd != 0 && rd != 2 && immFits(off, 6) {
-			o := uint16(p.From.Offset)
-			return uint16(0x6001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
-		}
-	// Integer Register-Immediate Operations
-	// cases of ADDI already handled
-	case AADDIW:
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rs1 == rd && rs1 != 0 && immFits(off, 6) {
-			// C.ADDIW // 001 imm[5] rs1/rd!=0 imm[4:0] 01
-			o := uint16(p.From.Offset)
-			return uint16(0x2001 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+		opc = 0x8001
+		if p.As == ASRAI {
+			opc = 0x8401
 		}
 	case AANDI:
-		// C.ANDI // 100 imm[5] 10 rs1'/rd' imm[4:0] 01
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rs1 == rd && rd >= 8 && rd <= 15 && immFits(off, 6) {
-			o := uint16(p.From.Offset)
-			return uint16(0x8801 | ((o>>5)&1)<<12 | uint16(rd&7)<<7 | (o&31)<<2)
-		}
-	case ASRLI:
-		// C.SRLI // 100 nzimm[5] 00 rs1'/rd' nzimm[4:0] 01
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rs1 == rd && rd >= 8 && rd <= 15 && off > 0 && off < 64 {
-			o := uint16(p.From.Offset)
-			return uint16(0x8001 | ((o>>5)&1)<<12 | uint16(rd&7)<<7 | (o&31)<<2)
-		}
-	case ASRAI:
-		// C.SRAI // 100 nzimm[5] 01 rs1'/rd' nzimm[4:0] 01
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rs1 == rd && rd >= 8 && rd <= 15 && off > 0 && off < 64 {
-			o := uint16(p.From.Offset)
-			return uint16(0x8401 | ((o>>5)&1)<<12 | uint16(rd&7)<<7 | (o&31)<<2)
-		}
-	case ASLLI:
-		// C.SLLI // 000 nzimm[5] rd!=0 nzimm[4:0] 10
-		rs1 := regi(*p.From3)
-		rd := regi(p.To)
-		if rs1 == rd && rs1 != 0 && off > 0 && off < 64 {
-			o := uint16(p.From.Offset)
-			return uint16(0x0002 | ((o>>5)&1)<<12 | uint16(rd)<<7 | (o&31)<<2)
+		if !immFits(off, 6) {
+			return 0
 		}
-	// Integer Register-Register Operations
-	// MV already handled (we generate it as ADDI)
-	case AADD:
-		// C.ADD // 100 1 rd!=0 rs2!=0 10
-		rs1 := regi(*p.From3)
-		rs2 := regi(p.From)
-		rd := regi(p.To)
-		if rd == rs1 && rd != 0 && rs2 != 0 {
-			return uint16(0x9002 | rd<<7 | rs2<<2)
+		opc = 0x8801
+	}
+	o := uint16(off)
+	return uint16(opc | ((o>>5)&1)<<12 | uint16(rd&7)<<7 | (o&31)<<2)
+}
+
+// Integer Register-Register Operations
+
+func compressMV(p *obj.Prog, sizing bool) uint16 {
+	// C.MV // 100 0 rd!=0 rs2!=0 10
+	rs1 := regi(*p.From3)
+	rd := regi(p.To)
+	if p.From.Offset == 0 && rs1 != 0 && rd != 0 {
+		return uint16(0x8002 | rd<<7 | rs1<<2)
+	}
+	return 0
+}
+
+func compressADD(p *obj.Prog, sizing bool) uint16 {
+	// C.ADD // 100 1 rd!=0 rs2!=0 10
+	rs1 := regi(*p.From3)
+	rs2 := regi(p.From)
+	rd := regi(p.To)
+	if rd == rs1 && rd != 0 && rs2 != 0 {
+		return uint16(0x9002 | rd<<7 | rs2<<2)
+	}
+	return 0
+}
+
+func compressArith(p *obj.Prog, sizing bool) uint16 {
+	// C.AND  // 100 0 11 rs1'/rd' 11 rs2' 01
+	// C.OR   // 100 0 11 rs1'/rd' 10 rs2' 01
+	// C.XOR  // 100 0 11 rs1'/rd' 01 rs2' 01
+	// C.SUB  // 100 0 11 rs1'/rd' 00 rs2' 01
+	// C.ADDW // 100 1 11 rs1'/rd' 01 rs2' 01
+	// C.SUBW // 100 1 11 rs1'/rd' 00 rs2' 01
+	rs1 := regi(*p.From3)
+	rs2 := regi(p.From)
+	rd := regi(p.To)
+	if rd != rs1 || !isCReg(rd) || !isCReg(rs2) {
+		return 0
+	}
+	var opc uint32
+	switch p.As {
+	case AAND:
+		opc = 0x8C61
+	case AOR:
+		opc = 0x8C41
+	case AXOR:
+		opc = 0x8C21
+	case ASUB:
+		opc = 0x8C01
+	case AADDW:
+		opc = 0x9C21
+	case ASUBW:
+		opc = 0x9C01
+	}
+	return uint16(opc | (rd&7)<<7 | (rs2&7)<<2)
+}
+
+func compressNOP(p *obj.Prog, sizing bool) uint16 {
+	// C.NOP // 000 0 0 0 01
+	if regi(*p.From3) == 0 && regi(p.To) == 0 && p.From.Offset == 0 {
+		return 0x0001
+	}
+	return 0
+}
+
+// 12.6: Breakpoint Instruction
+
+func compressEBREAK(p *obj.Prog, sizing bool) uint16 {
+	// C.EBREAK // 100 1 0 0 10
+	return 0x9002
+}
+
+// compressors lists the compressed forms of each instruction, in order of
+// preference.  Some instructions have 16-bit compressed encodings; they're
+// irregular, few in number, and not in machine readable form so just list
+// them.
+var compressors = map[obj.As][]compressor{
+	AFLD:    {compressFLDSP, compressFLD},
+	ALW:     {compressLWSP, compressLW},
+	ALD:     {compressLDSP, compressLD},
+	AFSD:    {compressFSDSP, compressFSD},
+	ASW:     {compressSWSP, compressSW},
+	ASD:     {compressSDSP, compressSD},
+	AJAL:    {compressJ},
+	AJALR:   {compressJR, compressJALR},
+	ABEQ:    {compressBranch},
+	ABNE:    {compressBranch},
+	AADDI:   {compressADDI16SP, compressADDI4SPN, compressADDI, compressNOP, compressLI, compressMV},
+	ALUI:    {compressLUI},
+	AADDIW:  {compressADDIW},
+	AANDI:   {compressShiftAndI},
+	ASRLI:   {compressShiftAndI},
+	ASRAI:   {compressShiftAndI},
+	ASLLI:   {compressSLLI},
+	AADD:    {compressADD},
+	AAND:    {compressArith},
+	AOR:     {compressArith},
+	AXOR:    {compressArith},
+	ASUB:    {compressArith},
+	AADDW:   {compressArith},
+	ASUBW:   {compressArith},
+	AEBREAK: {compressEBREAK},
+}
+
+// An explicitCompressed describes an explicitly compressed instruction,
+// e.g. CADDI.  It is assembled like the uncompressed instruction as, except
+// that it must be encoded in the compressed form c.
+type explicitCompressed struct {
+	as  obj.As
+	enc encoding
+	c   compressor
+}
+
+var explicitCompressedForAs = map[obj.As]explicitCompressed{
+	// 12.3: Load and Store Instructions
+	ACFLDSP: {AFLD, iFEncoding, compressFLDSP},
+	ACLWSP:  {ALW, iIEncoding, compressLWSP},
+	ACLDSP:  {ALD, iIEncoding, compressLDSP},
+	ACFSDSP: {AFSD, sFEncoding, compressFSDSP},
+	ACSWSP:  {ASW, sIEncoding, compressSWSP},
+	ACSDSP:  {ASD, sIEncoding, compressSDSP},
+	ACFLD:   {AFLD, iFEncoding, compressFLD},
+	ACLW:    {ALW, iIEncoding, compressLW},
+	ACLD:    {ALD, iIEncoding, compressLD},
+	ACFSD:   {AFSD, sFEncoding, compressFSD},
+	ACSW:    {ASW, sIEncoding, compressSW},
+	ACSD:    {ASD, sIEncoding, compressSD},
+
+	// 12.4: Control Transfer Instructions
+	ACJ:    {AJAL, ujEncoding, compressJ},
+	ACJR:   {AJALR, iIEncoding, compressJR},
+	ACJALR: {AJALR, iIEncoding, compressJALR},
+	ACBEQZ: {ABEQ, sbEncoding, compressBranch},
+	ACBNEZ: {ABNE, sbEncoding, compressBranch},
+
+	// 12.5: Integer Computational Instructions
+	ACLI:       {AADDI, iIEncoding, compressLI},
+	ACLUI:      {ALUI, uEncoding, compressLUI},
+	ACADDI:     {AADDI, iIEncoding, compressADDI},
+	ACADDIW:    {AADDIW, iIEncoding, compressADDIW},
+	ACADDI16SP: {AADDI, iIEncoding, compressADDI16SP},
+	ACADDI4SPN: {AADDI, iIEncoding, compressADDI4SPN},
+	ACSLLI:     {ASLLI, iIEncoding, compressSLLI},
+	ACSRLI:     {ASRLI, iIEncoding, compressShiftAndI},
+	ACSRAI:     {ASRAI, iIEncoding, compressShiftAndI},
+	ACANDI:     {AANDI, iIEncoding, compressShiftAndI},
+	ACMV:       {AADDI, iIEncoding, compressMV},
+	ACADD:      {AADD, rIIIEncoding, compressADD},
+	ACAND:      {AAND, rIIIEncoding, compressArith},
+	ACOR:       {AOR, rIIIEncoding, compressArith},
+	ACXOR:      {AXOR, rIIIEncoding, compressArith},
+	ACSUB:      {ASUB, rIIIEncoding, compressArith},
+	ACADDW:     {AADDW, rIIIEncoding, compressArith},
+	ACSUBW:     {ASUBW, rIIIEncoding, compressArith},
+	ACNOP:      {AADDI, iIEncoding, compressNOP},
+
+	// 12.6: Breakpoint Instruction
+	ACEBREAK: {AEBREAK, iIEncoding, compressEBREAK},
+}
+
+// compress returns the 16-bit encoding of p, or 0 if p is not to be
+// compressed.
+func compress(p *obj.Prog, sizing bool) uint16 {
+	if !GORISCVRVC {
+		return 0
+	}
+	if e, ok := explicitCompressedForAs[p.As]; ok {
+		if sizing {
+			// Always 2 bytes.  Operands are checked in
+			// validateCompressed.
+			return 0x9001
 		}
-	case AAND, AOR, AXOR, ASUB, AADDW, ASUBW:
-		// C.AND  // 100 0 11 rs1'/rd' 11 rs2' 01
-		// C.OR   // 100 0 11 rs1'/rd' 10 rs2' 01
-		// C.XOR  // 100 0 11 rs1'/rd' 01 rs2' 01
-		// C.SUB  // 100 0 11 rs1'/rd' 00 rs2' 01
-		// C.ADDW // 100 1 11 rs1'/rd' 01 rs2' 01
-		// C.SUBW // 100 1 11 rs1'/rd' 00 rs2' 01
-		rs1 := regi(*p.From3)
-		rs2 := regi(p.From)
-		rd := regi(p.To)
-		if rd == rs1 && rd >= 8 && rd <= 15 && rs2 >= 8 && rs2 <= 15 {
-			var opc uint32
-			switch p.As {
-			case AAND:
-				opc = 0x8C61
-			case AOR:
-				opc = 0x8C41
-			case AXOR:
-				opc = 0x8C21
-			case ASUB:
-				opc = 0x8C01
-			case AADDW:
-				opc = 0x9C21
-			case ASUBW:
-				opc = 0x9C01
-			}
-			return uint16(opc | (rd&7)<<7 | (rs2&7)<<2)
+		q := *p
+		q.As = e.as
+		return e.c(&q, sizing)
+	}
+	if p.Mark&(NOCOMPRESS|NORVC) != 0 {
+		return 0
+	}
+	for _, c := range compressors[p.As] {
+		if code := c(p, sizing); code != 0 {
+			return code
 		}
-	case AEBREAK:
-		return 0x9002
 	}
 	return 0
 }
 
+// validateCompressed checks that an explicitly compressed instruction has a
+// compressed encoding.
+func validateCompressed(p *obj.Prog) {
+	e := explicitCompressedForAs[p.As]
+	errors := p.Ctxt.Errors
+	e.enc.validate(p)
+	if p.Ctxt.Errors != errors {
+		return
+	}
+	if !GORISCVRVC {
+		p.Ctxt.Diag("%v\tcompressed instruction requires GORISCV=GC", p)
+		return
+	}
+	if compress(p, false) == 0 {
+		p.Ctxt.Diag("%v\toperands cannot be encoded in a compressed instruction", p)
+	}
+}
+
+// encodeCompressed encodes an explicitly compressed instruction.
+func encodeCompressed(p *obj.Prog) uint32 {
+	return uint32(compress(p, false))
+}
+
 // assemble emits machine code.
 // It is called at the very end of the assembly process.
 func assemble(ctxt *obj.Link, cursym *obj.LSym) {
@@ -2133,6 +2916,26 @@ func assemble(ctxt *obj.Link, cursym *obj.LSym) {
 			rel.Add = p.From.Offset
 			p.From.Offset = 0 // relocation offset can be larger than the maximum size of an auipc, so don't accidentally assemble it
 			rel.Type = t
+		case ALUI:
+			if p.Mark&NEED_TLS_LE_RELOC == 0 {
+				break
+			}
+			if p.Link == nil {
+				ctxt.Diag("LUI needing TLS reloc missing following instruction")
+				break
+			}
+			if p.From.Sym == nil {
+				ctxt.Diag("LUI needing TLS reloc missing symbol")
+				break
+			}
+
+			rel := obj.Addrel(cursym)
+			rel.Off = int32(p.Pc)
+			rel.Siz = 8
+			rel.Sym = p.From.Sym
+			rel.Add = p.From.Offset
+			p.From.Offset = 0
+			rel.Type = obj.R_RISCV_TLS_LE
 		}
 
 		if code := compress(p, false); code != 0 {
@@ -2143,7 +2946,9 @@ func assemble(ctxt *obj.Link, cursym *obj.LSym) {
 				code32 := enc.encode(p)
 				// parcels are always in little-endian order, even on big-endian variants
 				symcode = append(symcode, uint16(code32))
-				symcode = append(symcode, uint16(code32>>16))
+				if enc.length == 4 {
+					symcode = append(symcode, uint16(code32>>16))
+				}
 			}
 		}
 	}
@@ -111,7 +111,7 @@ const (
 	REG_RA   = REG_X1
 	REG_SP   = REG_X2
 	REG_GP   = REG_X3 // aka REG_SB
-	REG_TP   = REG_X4 // aka REG_G
+	REG_TP   = REG_X4
 	REG_T0   = REG_X5
 	REG_T1   = REG_X6
 	REG_T2   = REG_X7
@@ -134,16 +134,16 @@ const (
 	REG_S8   = REG_X24
 	REG_S9   = REG_X25
 	REG_S10  = REG_X26
-	REG_S11  = REG_X27
+	REG_S11  = REG_X27 // aka REG_G
 	REG_T3   = REG_X28
 	REG_T4   = REG_X29
 	REG_T5   = REG_X30
 	REG_T6   = REG_X31
 
 	// Go runtime register names.
-	REG_G    = REG_TP // G pointer.
-	REG_CTXT = REG_S4 // Context for closures.
-	REG_TMP  = REG_T6 // Reserved for assembler use.
+	REG_G    = REG_S11 // G pointer.
+	REG_CTXT = REG_S4  // Context for closures.
+	REG_TMP  = REG_T6  // Reserved for assembler use.
 
 	// ABI names for floating point registers.
 	REG_FT0  = REG_F0
@@ -203,10 +203,41 @@ const (
 	// R_RISCV_PCREL_STYPE relocation.
 	NEED_PCREL_STYPE_RELOC
 
+	// NEED_TLS_LE_RELOC is set on LUI instructions to indicate that it is
+	// the first instruction in a LUI + I-type pair that needs a
+	// R_RISCV_TLS_LE relocation.
+	NEED_TLS_LE_RELOC
+
 	// Disables compression.  Set on instructions which need a fixed size
 	// after preprocess, because of relocations or use in branch offset
 	// calculation.
 	NOCOMPRESS
+
+	// NORVC is set on instructions between NORVC and RVC directives.  It
+	// disables automatic compression like NOCOMPRESS, but is inherited by
+	// the instructions an instruction is expanded into.
+	NORVC
+
+	// LRSC is set on the instructions from an LR to the SC that ends its
+	// constrained loop, inclusive.  Their size and layout may not change
+	// after preprocess has checked them.
+	LRSC
+)
+
+// Floating-point rounding modes, stored in Prog.Scond.
+//
+// The zero value means no rounding mode was given. Such conversions from
+// floating point to integer are assembled with RTZ, which truncates as Go
+// does; every other instruction is assembled with RNE. The remaining values are one more than the rm field they encode to, except
+// for RM_DYN, which selects the dynamic rounding mode in frm.
+const (
+	RM_NONE = iota
+	RM_RNE  // Round to nearest, ties to even
+	RM_RTZ  // Round towards zero
+	RM_RDN  // Round down (towards -∞)
+	RM_RUP  // Round up (towards +∞)
+	RM_RMM  // Round to nearest, ties to max magnitude
+	RM_DYN  // Dynamic rounding mode, from frm
 )
 
 // RISC-V mnemonics, as defined in the "opcodes" and "opcodes-pseudo" files of
@@ -234,7 +265,9 @@ const (
 
 	// 2.7: Memory Model
 	AFENCE
+	AFENCETSO
 	AFENCEI
+	APAUSE
 
 	// 4.2: Integer Computational Instructions
 	AADDI
@@ -422,6 +455,51 @@ const (
 	// 8.6: Double-Precision Floating-Point Classify Instruction
 	AFCLASSD
 
+	// 12.3: Compressed Load and Store Instructions
+	ACLWSP
+	ACLDSP
+	ACFLDSP
+	ACSWSP
+	ACSDSP
+	ACFSDSP
+	ACLW
+	ACLD
+	ACFLD
+	ACSW
+	ACSD
+	ACFSD
+
+	// 12.4: Compressed Control Transfer Instructions
+	ACJ
+	ACJR
+	ACJALR
+	ACBEQZ
+	ACBNEZ
+
+	// 12.5: Compressed Integer Computational Instructions
+	ACLI
+	ACLUI
+	ACADDI
+	ACADDIW
+	ACADDI16SP
+	ACADDI4SPN
+	ACSLLI
+	ACSRLI
+	ACSRAI
+	ACANDI
+	ACMV
+	ACADD
+	ACAND
+	ACOR
+	ACXOR
+	ACSUB
+	ACADDW
+	ACSUBW
+	ACNOP
+
+	// 12.6: Compressed Breakpoint Instruction
+	ACEBREAK
+
 	// Privileged ISA
 
 	// 2.1: Instructions to Access CSRs
@@ -432,24 +510,65 @@ const (
 	ACSRRSI
 	ACSRRCI
 
-	// 3.2.1: Instructions to Change Privilege Level
+	// 3.3.1: Environment Call and Breakpoint
 	AECALL
 	ASCALL
 	AEBREAK
 	ASBREAK
-	AERET
-	ASRET
 
-	// 3.2.2: Trap Redirection Instructions
-	AMRTS
-	AMRTH
-	AHRTS
+	// 3.3.2: Trap-Return Instructions
+	AMRET
+	ASRET
 
-	// 3.2.3: Wait for Interrupt
+	// 3.3.3: Wait for Interrupt
 	AWFI
 
 	// 4.2.1: Supervisor Memory-Management Fence Instruction
-	ASFENCEVM
+	ASFENCEVMA
+
+	// 8.3.2: Hypervisor Memory-Management Fence Instructions
+	AHFENCEVVMA
+	AHFENCEGVMA
+
+	// Cache-Block Operations (Zicbom, Zicboz)
+	ACBOCLEAN
+	ACBOFLUSH
+	ACBOINVAL
+	ACBOZERO
+
+	// Bit-Manipulation (Zbb, Zbkb)
+	AANDN
+	AORN
+	AXNOR
+	AROL
+	AROR
+	ARORI
+	AROLW
+	ARORW
+	ARORIW
+	AREV8
+
+	// Carry-less Multiplication (Zbc)
+	ACLMUL
+	ACLMULH
+	ACLMULR
+
+	// Scalar Cryptography (Zknd, Zkne, Zknh)
+	AAES64DS
+	AAES64DSM
+	AAES64ES
+	AAES64ESM
+	AAES64IM
+	AAES64KS1I
+	AAES64KS2
+	ASHA256SIG0
+	ASHA256SIG1
+	ASHA256SUM0
+	ASHA256SUM1
+	ASHA512SIG0
+	ASHA512SIG1
+	ASHA512SUM0
+	ASHA512SUM1
 
 	// The escape hatch. Inserts a single 32-bit word.
 	AWORD
@@ -471,6 +590,12 @@ const (
 	AMOVWU
 	ASEQZ
 	ASNEZ
+
+	// Assembler directives.  NORVC disables automatic compression of the
+	// instructions that follow it, up to the next RVC or the end of the
+	// function; explicitly compressed instructions are unaffected.
+	ANORVC
+	ARVC
 )
 
 // All unary instructions which write to their arguments (as opposed to reading
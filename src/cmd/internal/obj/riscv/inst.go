@@ -114,8 +114,12 @@ func encode(a obj.As) (i *inst, ok bool) {
 		return &inst{0x23, 0x3, 0x0, 0, 0x0}, true
 	case AFENCE:
 		return &inst{0xf, 0x0, 0x0, 0, 0x0}, true
+	case AFENCETSO:
+		return &inst{0xf, 0x0, 0x13, -1997, 0x41}, true
 	case AFENCEI:
 		return &inst{0xf, 0x1, 0x0, 0, 0x0}, true
+	case APAUSE:
+		return &inst{0xf, 0x0, 0x10, 16, 0x0}, true
 	case AMUL:
 		return &inst{0x33, 0x0, 0x0, 32, 0x1}, true
 	case AMULH:
@@ -190,18 +194,82 @@ func encode(a obj.As) (i *inst, ok bool) {
 		return &inst{0x73, 0x0, 0x0, 0, 0x0}, true
 	case ASBREAK:
 		return &inst{0x73, 0x0, 0x1, 1, 0x0}, true
+	case AMRET:
+		return &inst{0x73, 0x0, 0x2, 770, 0x18}, true
 	case ASRET:
-		return &inst{0x73, 0x0, 0x0, 256, 0x8}, true
-	case ASFENCEVM:
-		return &inst{0x73, 0x0, 0x1, 257, 0x8}, true
-	case AWFI:
 		return &inst{0x73, 0x0, 0x2, 258, 0x8}, true
-	case AMRTH:
-		return &inst{0x73, 0x0, 0x6, 774, 0x18}, true
-	case AMRTS:
-		return &inst{0x73, 0x0, 0x5, 773, 0x18}, true
-	case AHRTS:
-		return &inst{0x73, 0x0, 0x5, 517, 0x10}, true
+	case AWFI:
+		return &inst{0x73, 0x0, 0x5, 261, 0x8}, true
+	case ASFENCEVMA:
+		return &inst{0x73, 0x0, 0x0, 288, 0x9}, true
+	case AHFENCEVVMA:
+		return &inst{0x73, 0x0, 0x0, 544, 0x11}, true
+	case AHFENCEGVMA:
+		return &inst{0x73, 0x0, 0x0, 1568, 0x31}, true
+	case ACBOCLEAN:
+		return &inst{0xf, 0x2, 0x1, 1, 0x0}, true
+	case ACBOFLUSH:
+		return &inst{0xf, 0x2, 0x2, 2, 0x0}, true
+	case ACBOINVAL:
+		return &inst{0xf, 0x2, 0x0, 0, 0x0}, true
+	case ACBOZERO:
+		return &inst{0xf, 0x2, 0x4, 4, 0x0}, true
+	case AANDN:
+		return &inst{0x33, 0x7, 0x0, 0, 0x20}, true
+	case AORN:
+		return &inst{0x33, 0x6, 0x0, 0, 0x20}, true
+	case AXNOR:
+		return &inst{0x33, 0x4, 0x0, 0, 0x20}, true
+	case AROL:
+		return &inst{0x33, 0x1, 0x0, 0, 0x30}, true
+	case AROR:
+		return &inst{0x33, 0x5, 0x0, 0, 0x30}, true
+	case ARORI:
+		return &inst{0x13, 0x5, 0x0, 1536, 0x30}, true
+	case AROLW:
+		return &inst{0x3b, 0x1, 0x0, 0, 0x30}, true
+	case ARORW:
+		return &inst{0x3b, 0x5, 0x0, 0, 0x30}, true
+	case ARORIW:
+		return &inst{0x1b, 0x5, 0x0, 1536, 0x30}, true
+	case AREV8:
+		return &inst{0x13, 0x5, 0x18, 1720, 0x35}, true
+	case ACLMUL:
+		return &inst{0x33, 0x1, 0x0, 0, 0x5}, true
+	case ACLMULH:
+		return &inst{0x33, 0x3, 0x0, 0, 0x5}, true
+	case ACLMULR:
+		return &inst{0x33, 0x2, 0x0, 0, 0x5}, true
+	case AAES64DS:
+		return &inst{0x33, 0x0, 0x0, 0, 0x1d}, true
+	case AAES64DSM:
+		return &inst{0x33, 0x0, 0x0, 0, 0x1f}, true
+	case AAES64ES:
+		return &inst{0x33, 0x0, 0x0, 0, 0x19}, true
+	case AAES64ESM:
+		return &inst{0x33, 0x0, 0x0, 0, 0x1b}, true
+	case AAES64IM:
+		return &inst{0x13, 0x1, 0x0, 768, 0x18}, true
+	case AAES64KS1I:
+		return &inst{0x13, 0x1, 0x10, 784, 0x18}, true
+	case AAES64KS2:
+		return &inst{0x33, 0x0, 0x0, 0, 0x3f}, true
+	case ASHA256SIG0:
+		return &inst{0x13, 0x1, 0x2, 258, 0x8}, true
+	case ASHA256SIG1:
+		return &inst{0x13, 0x1, 0x3, 259, 0x8}, true
+	case ASHA256SUM0:
+		return &inst{0x13, 0x1, 0x0, 256, 0x8}, true
+	case ASHA256SUM1:
+		return &inst{0x13, 0x1, 0x1, 257, 0x8}, true
+	case ASHA512SIG0:
+		return &inst{0x13, 0x1, 0x6, 262, 0x8}, true
+	case ASHA512SIG1:
+		return &inst{0x13, 0x1, 0x7, 263, 0x8}, true
+	case ASHA512SUM0:
+		return &inst{0x13, 0x1, 0x4, 260, 0x8}, true
+	case ASHA512SUM1:
+		return &inst{0x13, 0x1, 0x5, 261, 0x8}, true
 	case ACSRRW:
 		return &inst{0x73, 0x1, 0x0, 0, 0x0}, true
 	case ACSRRS:
@@ -376,8 +444,6 @@ func encode(a obj.As) (i *inst, ok bool) {
 		return &inst{0x73, 0x0, 0x0, 0, 0x0}, true
 	case AEBREAK:
 		return &inst{0x73, 0x0, 0x1, 1, 0x0}, true
-	case AERET:
-		return &inst{0x73, 0x0, 0x0, 256, 0x8}, true
 	}
 	return nil, false
 }
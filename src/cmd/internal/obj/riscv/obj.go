@@ -25,6 +25,15 @@ import (
 	"cmd/internal/sys"
 )
 
+func init() {
+	// Without the C extension every instruction is four bytes long,
+	// and pc-value tables count in four-byte steps to match the
+	// runtime's PCQuantum.
+	if !GORISCVRVC {
+		sys.ArchRISCV.MinLC = 4
+	}
+}
+
 var LinkRISCV = obj.LinkArch{
 	Arch:       sys.ArchRISCV,
 	Preprocess: preprocess,
@@ -6,6 +6,7 @@ package obj
 
 import (
 	"bytes"
+	"cmd/internal/sys"
 	"fmt"
 	"log"
 	"os"
@@ -37,6 +38,7 @@ var (
 	GOOS    = envOr("GOOS", defaultGOOS)
 	GO386   = envOr("GO386", defaultGO386)
 	GOARM   = goarm()
+	GORISCV = goriscv()
 	Version = version
 )
 
@@ -54,6 +56,33 @@ func goarm() int {
 	panic("unreachable")
 }
 
+func goriscv() string {
+	v := envOr("GORISCV", defaultGORISCV)
+	exts := strings.Split(v, "_")
+	if exts[0] != "G" && exts[0] != "GC" {
+		// Fail here, rather than validate at multiple call sites.
+		log.Fatalf("Invalid GORISCV value. Must be G or GC, optionally followed by extensions such as _zbb.")
+	}
+	for _, ext := range exts[1:] {
+		if ext != "zbb" && ext != "zbc" && ext != "zic64b" && ext != "zkn" {
+			log.Fatalf("Invalid GORISCV value. Unknown extension %s.", ext)
+		}
+	}
+	// The runtime's PCQuantum is a constant chosen by make.bash,
+	// so GORISCV cannot be changed without rebuilding the toolchain.
+	// It means nothing when building for other architectures.
+	if v != defaultGORISCV && GOARCH == "riscv" {
+		log.Fatalf("GORISCV=%s does not match the GORISCV=%s this toolchain was built with. Rerun make.bash.", v, defaultGORISCV)
+	}
+	return v
+}
+
+// GORISCVExtensions returns the optional extensions that follow the base
+// instruction set in GORISCV, such as zbb and zkn in GC_zbb_zkn.
+func GORISCVExtensions() []string {
+	return strings.Split(GORISCV, "_")[1:]
+}
+
 func Getgoextlinkenabled() string {
 	return envOr("GO_EXTLINK_ENABLED", defaultGO_EXTLINK_ENABLED)
 }
@@ -123,6 +152,9 @@ func (p *Prog) String() string {
 	}
 
 	sc := CConv(p.Scond)
+	if cconv := opSuffixSpace[p.Ctxt.Arch.Family]; cconv != nil && p.Scond != 0 {
+		sc = cconv(p.Scond)
+	}
 
 	var buf bytes.Buffer
 
@@ -397,6 +429,17 @@ func RegisterRegister(lo, hi int, Rconv func(int) string) {
 	regSpace = append(regSpace, regSet{lo, hi, Rconv})
 }
 
+// opSuffixSpace holds the Scond pretty-printers registered by
+// architectures which do not use ARM-style condition codes.
+var opSuffixSpace = make(map[sys.ArchFamily]func(uint8) string)
+
+// RegisterOpSuffix binds a pretty-printer for Prog.Scond to the given
+// architecture family, replacing CConv when formatting its Progs.
+// The pretty-printer is never called with a zero Scond.
+func RegisterOpSuffix(family sys.ArchFamily, cconv func(uint8) string) {
+	opSuffixSpace[family] = cconv
+}
+
 func Rconv(reg int) string {
 	if reg == REG_NONE {
 		return "NONE"
@@ -0,0 +1,401 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmd/internal/dwarf"
+	debugdwarf "debug/dwarf"
+	"debug/elf"
+	"encoding/binary"
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const riscvUnwindSrc = `
+package main
+
+//go:noinline
+func c(x int) int {
+	var buf [16]int
+	for i := range buf {
+		buf[i] = x + i
+	}
+	return buf[x&15]
+}
+
+//go:noinline
+func b(x int) int { return c(x+1) + 1 }
+
+//go:noinline
+func a(x int) int { return b(x+1) + 1 }
+
+func main() { println(a(1)) }
+`
+
+// TestRISCVUnwind cross-builds a linux/riscv program and checks that
+// its .debug_frame describes the prologue, body and epilogue of each
+// function, then uses it to unwind a synthetic main -> a -> b -> c stack.
+func TestRISCVUnwind(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping riscv cross build in short mode")
+	}
+	testenv.MustHaveGoBuild(t)
+
+	tmpdir, err := ioutil.TempDir("", "riscvunwind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte(riscvUnwindSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(tmpdir, "main")
+	cmd := exec.Command(testenv.GoToolPath(t), "build", "-o", exe, src)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "GOOS=") && !strings.HasPrefix(kv, "GOARCH=") {
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	cmd.Env = append(cmd.Env, "GOOS=linux", "GOARCH=riscv")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	f, err := elf.Open(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// e_flags must name the double-float ABI, or debuggers will look
+	// for FP arguments and results in the integer registers.
+	data, err := ioutil.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags := binary.LittleEndian.Uint32(data[48:]); flags&0x6 != 0x4 {
+		t.Errorf("e_flags = %#x, want double-float ABI", flags)
+	}
+
+	funcs := riscvFuncs(t, f, "main.main", "main.a", "main.b", "main.c")
+	text := f.Section(".text")
+	textData, err := text.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	insts := func(fn riscvFunc) []riscvInst {
+		return decodeRISCV(textData[fn.lo-text.Addr:fn.hi-text.Addr], fn.lo)
+	}
+
+	frame, err := f.Section(".debug_frame").Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := parseDebugFrame(t, frame)
+
+	// Every function starts and returns with the frame released and
+	// RA live in its register, and keeps RA at 0(SP) in between.
+	frameSize := map[string]int64{}
+	bodyPC := map[string]uint64{}
+	for _, name := range []string{"main.a", "main.b", "main.c"} {
+		fn := funcs[name]
+		if r := rows.lookup(t, fn.lo); r.cfa != 0 || r.raSaved {
+			t.Errorf("%s entry: CFA=SP+%d RA saved=%v, want CFA=SP RA in register", name, r.cfa, r.raSaved)
+		}
+		var ret uint64
+		for i, inst := range insts(fn) {
+			if size, ok := inst.storeRA(); ok && frameSize[name] == 0 {
+				frameSize[name] = size
+				// Skip the SP adjustment that follows.
+				bodyPC[name] = insts(fn)[i+2].pc
+			}
+			if inst.isRet() {
+				ret = inst.pc
+			}
+		}
+		if frameSize[name] == 0 || ret == 0 {
+			t.Fatalf("%s: no RA spill or no RET found", name)
+		}
+		if r := rows.lookup(t, bodyPC[name]); r.cfa != frameSize[name] || !r.raSaved || r.ra != -frameSize[name] {
+			t.Errorf("%s body: CFA=SP+%d RA at CFA%+d, want CFA=SP+%d RA at CFA-%d", name, r.cfa, r.ra, frameSize[name], frameSize[name])
+		}
+		if r := rows.lookup(t, ret); r.cfa != 0 || r.raSaved {
+			t.Errorf("%s RET: CFA=SP+%d RA saved=%v, want CFA=SP RA in register", name, r.cfa, r.raSaved)
+		}
+	}
+
+	// Lay out the stack of c interrupted in its body, with each frame
+	// holding its caller's return address at 0(SP).
+	callers := []struct{ caller, callee string }{{"main.b", "main.c"}, {"main.a", "main.b"}, {"main.main", "main.a"}}
+	var want []uint64
+	for _, cc := range callers {
+		var ret uint64
+		for _, inst := range insts(funcs[cc.caller]) {
+			if target, ok := inst.callTarget(); ok && target == funcs[cc.callee].lo {
+				ret = inst.pc + uint64(inst.size)
+			}
+		}
+		if ret == 0 {
+			t.Fatalf("no call from %s to %s", cc.caller, cc.callee)
+		}
+		want = append(want, ret)
+	}
+	mem := map[uint64]uint64{}
+	frameSP := uint64(0x10000)
+	for i, name := range []string{"main.c", "main.b", "main.a"} {
+		mem[frameSP] = want[i]
+		frameSP += uint64(frameSize[name])
+	}
+
+	pc, sp := bodyPC["main.c"], uint64(0x10000)
+	for i := range want {
+		lookupPC := pc
+		if i > 0 {
+			// Return addresses point after the call.
+			lookupPC--
+		}
+		r := rows.lookup(t, lookupPC)
+		if !r.raSaved {
+			t.Fatalf("frame %d at %#x: RA not saved", i, pc)
+		}
+		cfa := sp + uint64(r.cfa)
+		pc, sp = mem[cfa+uint64(r.ra)], cfa
+		if pc != want[i] {
+			t.Fatalf("frame %d: unwound to %#x, want %#x", i, pc, want[i])
+		}
+	}
+}
+
+type riscvFunc struct {
+	lo, hi uint64
+}
+
+// riscvFuncs finds the named functions' PC ranges in the DWARF info.
+func riscvFuncs(t *testing.T, f *elf.File, names ...string) map[string]riscvFunc {
+	d, err := f.DWARF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	funcs := map[string]riscvFunc{}
+	r := d.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e == nil {
+			break
+		}
+		if e.Tag != debugdwarf.TagSubprogram {
+			continue
+		}
+		name, _ := e.Val(debugdwarf.AttrName).(string)
+		lo, _ := e.Val(debugdwarf.AttrLowpc).(uint64)
+		hi, _ := e.Val(debugdwarf.AttrHighpc).(uint64)
+		funcs[name] = riscvFunc{lo, hi}
+	}
+	for _, name := range names {
+		if _, ok := funcs[name]; !ok {
+			t.Fatalf("no DW_TAG_subprogram for %s", name)
+		}
+	}
+	return funcs
+}
+
+type riscvInst struct {
+	pc   uint64
+	size int
+	bits uint32
+}
+
+func decodeRISCV(b []byte, pc uint64) []riscvInst {
+	var insts []riscvInst
+	for len(b) >= 2 {
+		inst := riscvInst{pc: pc, size: 2, bits: uint32(binary.LittleEndian.Uint16(b))}
+		if inst.bits&3 == 3 && len(b) >= 4 {
+			inst.size = 4
+			inst.bits = binary.LittleEndian.Uint32(b)
+		}
+		insts = append(insts, inst)
+		b = b[inst.size:]
+		pc += uint64(inst.size)
+	}
+	return insts
+}
+
+// storeRA reports whether inst is the prologue's SD RA, -size(SP).
+func (inst riscvInst) storeRA() (int64, bool) {
+	const sdRASP = 0x23 | 3<<12 | 2<<15 | 1<<20
+	if inst.size != 4 || inst.bits&0x01fff07f != sdRASP {
+		return 0, false
+	}
+	imm := int64(int32(inst.bits&0xfe000000)>>20) | int64(inst.bits>>7&0x1f)
+	return -imm, imm < 0
+}
+
+// isRet reports whether inst is JALR ZERO, 0(RA) or its compressed form.
+func (inst riscvInst) isRet() bool {
+	return inst.size == 2 && inst.bits == 0x8082 || inst.size == 4 && inst.bits == 0x00008067
+}
+
+// callTarget returns the target of a JAL RA.
+func (inst riscvInst) callTarget() (uint64, bool) {
+	if inst.size != 4 || inst.bits&0xfff != 0x0ef {
+		return 0, false
+	}
+	x := inst.bits
+	imm := int64(int32(x&0x80000000)>>11) | int64(x&0xff000) | int64(x>>9&0x800) | int64(x>>20&0x7fe)
+	return inst.pc + uint64(imm), true
+}
+
+// A frameRow is the unwind rule from pc up to the next row:
+// CFA is SP+cfa, and RA is either live or saved at CFA+ra.
+type frameRow struct {
+	pc      uint64
+	cfa     int64
+	raSaved bool
+	ra      int64
+}
+
+type frameRows []frameRow
+
+func (rows frameRows) lookup(t *testing.T, pc uint64) frameRow {
+	var found *frameRow
+	for i := range rows {
+		if rows[i].pc <= pc && (found == nil || rows[i].pc >= found.pc) {
+			found = &rows[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("no .debug_frame row for %#x", pc)
+	}
+	return *found
+}
+
+// parseDebugFrame runs the CFA programs in the linker's .debug_frame,
+// which uses a single CIE and the handful of opcodes below.
+func parseDebugFrame(t *testing.T, b []byte) frameRows {
+	const (
+		spReg = 2
+		raReg = 1
+	)
+	var (
+		rows      frameRows
+		dataAlign int64
+		initial   []byte
+	)
+	for len(b) > 0 {
+		length := binary.LittleEndian.Uint32(b)
+		entry := b[4 : 4+length]
+		b = b[4+length:]
+		id := binary.LittleEndian.Uint32(entry)
+		entry = entry[4:]
+		if id == 0xffffffff {
+			// CIE: version, augmentation "", code and data alignment,
+			// return address register, initial instructions.
+			entry = entry[2:]
+			_, entry = uleb(entry)
+			dataAlign, entry = sleb(entry)
+			ra, rest := uleb(entry)
+			if ra != raReg {
+				t.Fatalf("CIE return address register = %d, want %d", ra, raReg)
+			}
+			initial = rest
+			continue
+		}
+		row := frameRow{pc: binary.LittleEndian.Uint64(entry)}
+		end := row.pc + binary.LittleEndian.Uint64(entry[8:])
+		prog := append(append([]byte(nil), initial...), entry[16:]...)
+		for len(prog) > 0 && row.pc < end {
+			op := prog[0]
+			prog = prog[1:]
+			var reg, off uint64
+			var soff int64
+			switch {
+			case op&0xc0 == dwarf.DW_CFA_advance_loc:
+				rows = append(rows, row)
+				row.pc += uint64(op & 0x3f)
+			case op == dwarf.DW_CFA_nop:
+			case op == dwarf.DW_CFA_advance_loc1:
+				rows = append(rows, row)
+				row.pc += uint64(prog[0])
+				prog = prog[1:]
+			case op == dwarf.DW_CFA_advance_loc2:
+				rows = append(rows, row)
+				row.pc += uint64(binary.LittleEndian.Uint16(prog))
+				prog = prog[2:]
+			case op == dwarf.DW_CFA_advance_loc4:
+				rows = append(rows, row)
+				row.pc += uint64(binary.LittleEndian.Uint32(prog))
+				prog = prog[4:]
+			case op == dwarf.DW_CFA_def_cfa:
+				reg, prog = uleb(prog)
+				off, prog = uleb(prog)
+				if reg != spReg {
+					t.Fatalf("CFA defined in terms of register %d, want SP", reg)
+				}
+				row.cfa = int64(off)
+			case op == dwarf.DW_CFA_def_cfa_offset_sf:
+				soff, prog = sleb(prog)
+				row.cfa = soff * dataAlign
+			case op == dwarf.DW_CFA_same_value:
+				reg, prog = uleb(prog)
+				if reg == raReg {
+					row.raSaved = false
+				}
+			case op == dwarf.DW_CFA_offset_extended_sf:
+				reg, prog = uleb(prog)
+				soff, prog = sleb(prog)
+				if reg == raReg {
+					row.raSaved, row.ra = true, soff*dataAlign
+				}
+			case op == dwarf.DW_CFA_val_offset:
+				reg, prog = uleb(prog)
+				off, prog = uleb(prog)
+				if reg != spReg || off != 0 {
+					t.Fatalf("DW_CFA_val_offset %d, %d: want SP = CFA", reg, off)
+				}
+			default:
+				t.Fatalf("unexpected CFA opcode %#x", op)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func uleb(b []byte) (uint64, []byte) {
+	var v uint64
+	for shift := uint(0); ; shift += 7 {
+		c := b[0]
+		b = b[1:]
+		v |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return v, b
+		}
+	}
+}
+
+func sleb(b []byte) (int64, []byte) {
+	var v int64
+	for shift := uint(0); ; shift += 7 {
+		c := b[0]
+		b = b[1:]
+		v |= int64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			if c&0x40 != 0 {
+				v |= -1 << (shift + 7)
+			}
+			return v, b
+		}
+	}
+}
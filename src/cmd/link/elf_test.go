@@ -0,0 +1,100 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"debug/elf"
+	"internal/testenv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRISCVBareMetal cross-builds a none/riscv program and checks that
+// it can be booted by firmware that jumps to a fixed address: the entry
+// point is the first thing there and nothing is loaded below it. A boot
+// ROM jumps to the start of RAM, and SBI firmware to 0x80200000.
+func TestRISCVBareMetal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping riscv cross build in short mode")
+	}
+	testenv.MustHaveGoBuild(t)
+
+	tmpdir, err := ioutil.TempDir("", "riscvbaremetal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	src := filepath.Join(tmpdir, "main.go")
+	if err := ioutil.WriteFile(src, []byte("package main\n\nfunc main() { println(\"hello\") }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	const ramBase = 0x80000000
+	for _, tt := range []struct {
+		ldflags string
+		entry   string
+		addr    uint64
+	}{
+		{"", "_rt0_riscv_none", ramBase},
+		{"-E _rt0_riscv_none_sbi -T 0x80200000", "_rt0_riscv_none_sbi", 0x80200000},
+	} {
+		exe := filepath.Join(tmpdir, "main")
+		cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags="+tt.ldflags, "-o", exe, src)
+		for _, kv := range os.Environ() {
+			if !strings.HasPrefix(kv, "GOOS=") && !strings.HasPrefix(kv, "GOARCH=") {
+				cmd.Env = append(cmd.Env, kv)
+			}
+		}
+		cmd.Env = append(cmd.Env, "GOOS=none", "GOARCH=riscv")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go build -ldflags=%q: %v\n%s", tt.ldflags, err, out)
+		}
+		checkRISCVBareMetal(t, exe, tt.entry, tt.addr)
+	}
+}
+
+func checkRISCVBareMetal(t *testing.T, exe, entry string, addr uint64) {
+	f, err := elf.Open(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if f.Entry != addr {
+		t.Errorf("%s: entry point is %#x, want %#x", entry, f.Entry, addr)
+	}
+	syms, err := f.Symbols()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, s := range syms {
+		if s.Name == entry {
+			found = true
+			if s.Value != addr {
+				t.Errorf("%s is at %#x, want %#x", entry, s.Value, addr)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no %s symbol", entry)
+	}
+
+	for _, p := range f.Progs {
+		switch p.Type {
+		case elf.PT_PHDR:
+			t.Errorf("%s: unexpected PT_PHDR at %#x", entry, p.Vaddr)
+		case elf.PT_LOAD:
+			if p.Paddr < addr {
+				t.Errorf("%s: segment loaded at %#x, below %#x", entry, p.Paddr, addr)
+			}
+		}
+	}
+}
@@ -1250,6 +1250,9 @@ func writeframes(ctxt *Link, syms []*Symbol) []*Symbol {
 				// TODO(bryanpkc): This is imprecise. In general, the instruction
 				// that stores the return address to the stack frame is not the
 				// same one that allocates the frame.
+				// On riscv it is exact: RA is stored below SP before the frame
+				// is allocated and reloaded before it is released, and the
+				// stack split check calls morestack with T0, not RA.
 				if pcsp.value > 0 {
 					// The return address is preserved at (CFA-frame_size)
 					// after a stack frame has been allocated.
@@ -762,6 +762,9 @@ const (
 	R_390_GOTPLT20    = 59
 	R_390_TLS_GOTIE20 = 60
 
+	EF_RISCV_RVC              = 0x0001
+	EF_RISCV_FLOAT_ABI_DOUBLE = 0x0004
+
 	ARM_MAGIC_TRAMP_NUMBER = 0x5c000003
 )
 
@@ -939,6 +942,14 @@ func Elfinit(ctxt *Link) {
 		if SysArch.Family == sys.MIPS64 {
 			ehdr.flags = 0x20000000 /* MIPS 3 */
 		}
+		if SysArch.Family == sys.RISCV {
+			// Debuggers and disassemblers read these to decide whether
+			// to decode compressed instructions and where FP values live.
+			ehdr.flags = EF_RISCV_FLOAT_ABI_DOUBLE
+			if strings.HasPrefix(obj.GORISCV, "GC") {
+				ehdr.flags |= EF_RISCV_RVC
+			}
+		}
 		elf64 = true
 
 		ehdr.phoff = ELF64HDRSIZE      /* Must be be ELF64HDRSIZE: first PHdr must follow ELF header */
@@ -1258,6 +1269,37 @@ func elfwritenotehdr(str string, namesz uint32, descsz uint32, tag uint32) *ElfS
 	return sh
 }
 
+// FreeBSD ABI tag (as per sys/sys/elf_common.h)
+const (
+	ELF_NOTE_FREEBSD_NAMESZ  = 8
+	ELF_NOTE_FREEBSD_DESCSZ  = 4
+	ELF_NOTE_FREEBSD_ABI_TAG = 1
+	ELF_NOTE_FREEBSD_VERSION = 1200000 /* FreeBSD 12.0 */
+)
+
+var ELF_NOTE_FREEBSD_NAME = []byte("FreeBSD\x00")
+
+func elffreebsdsig(sh *ElfShdr, startva uint64, resoff uint64) int {
+	n := ELF_NOTE_FREEBSD_NAMESZ + ELF_NOTE_FREEBSD_DESCSZ
+	return elfnote(sh, startva, resoff, n, true)
+}
+
+func elfwritefreebsdsig() int {
+	// Write Elf_Note header.
+	sh := elfwritenotehdr(".note.tag", ELF_NOTE_FREEBSD_NAMESZ, ELF_NOTE_FREEBSD_DESCSZ, ELF_NOTE_FREEBSD_ABI_TAG)
+
+	if sh == nil {
+		return 0
+	}
+
+	// Followed by FreeBSD string and version.
+	Cwrite(ELF_NOTE_FREEBSD_NAME)
+
+	Thearch.Lput(ELF_NOTE_FREEBSD_VERSION)
+
+	return int(sh.size)
+}
+
 // NetBSD Signature (as per sys/exec_elf.h)
 const (
 	ELF_NOTE_NETBSD_NAMESZ  = 7
@@ -1904,6 +1946,9 @@ func (ctxt *Link) doelf() {
 			Addstring(shstrtab, ".tbss")
 		}
 	}
+	if Headtype == obj.Hfreebsd {
+		Addstring(shstrtab, ".note.tag")
+	}
 	if Headtype == obj.Hnetbsd {
 		Addstring(shstrtab, ".note.netbsd.ident")
 	}
@@ -2268,21 +2313,25 @@ func Asmbelf(ctxt *Link, symo int64) {
 	}
 
 	/* program header info */
-	pph = newElfPhdr()
+	// On bare metal nothing may precede the entry point, so the
+	// headers are not loaded and there is no PHDR.
+	if Headtype != obj.Hnone {
+		pph = newElfPhdr()
 
-	pph.type_ = PT_PHDR
-	pph.flags = PF_R
-	pph.off = uint64(eh.ehsize)
-	pph.vaddr = uint64(*FlagTextAddr) - uint64(HEADR) + pph.off
-	pph.paddr = uint64(*FlagTextAddr) - uint64(HEADR) + pph.off
-	pph.align = uint64(*FlagRound)
+		pph.type_ = PT_PHDR
+		pph.flags = PF_R
+		pph.off = uint64(eh.ehsize)
+		pph.vaddr = uint64(*FlagTextAddr) - uint64(HEADR) + pph.off
+		pph.paddr = uint64(*FlagTextAddr) - uint64(HEADR) + pph.off
+		pph.align = uint64(*FlagRound)
+	}
 
 	/*
 	 * PHDR must be in a loaded segment. Adjust the text
 	 * segment boundaries downwards to include it.
 	 * Except on NaCl where it must not be loaded.
 	 */
-	if Headtype != obj.Hnacl {
+	if pph != nil && Headtype != obj.Hnacl {
 		o := int64(Segtext.Vaddr - pph.vaddr)
 		Segtext.Vaddr -= uint64(o)
 		Segtext.Length += uint64(o)
@@ -2329,9 +2378,13 @@ func Asmbelf(ctxt *Link, symo int64) {
 	}
 
 	pnote = nil
-	if Headtype == obj.Hnetbsd || Headtype == obj.Hopenbsd {
+	if Headtype == obj.Hfreebsd || Headtype == obj.Hnetbsd || Headtype == obj.Hopenbsd {
 		var sh *ElfShdr
 		switch Headtype {
+		case obj.Hfreebsd:
+			sh = elfshname(".note.tag")
+			resoff -= int64(elffreebsdsig(sh, uint64(startva), uint64(resoff)))
+
 		case obj.Hnetbsd:
 			sh = elfshname(".note.netbsd.ident")
 			resoff -= int64(elfnetbsdsig(sh, uint64(startva), uint64(resoff)))
@@ -2694,6 +2747,9 @@ elfobj:
 		a += int64(elfwriteinterp())
 	}
 	if Linkmode != LinkExternal {
+		if Headtype == obj.Hfreebsd {
+			a += int64(elfwritefreebsdsig())
+		}
 		if Headtype == obj.Hnetbsd {
 			a += int64(elfwritenetbsdsig())
 		}
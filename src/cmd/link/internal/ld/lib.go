@@ -644,6 +644,19 @@ func (ctxt *Link) loadlib() {
 		}
 	}
 
+	if Headtype == obj.Hnone {
+		// There is no loader on bare metal: the boot ROM jumps to
+		// the start of the image, so the entry point goes first.
+		entry := ctxt.Syms.ROLookup(*flagEntrySymbol, 0)
+		for i, s := range ctxt.Textp {
+			if s == entry {
+				copy(ctxt.Textp[1:i+1], ctxt.Textp[:i])
+				ctxt.Textp[0] = entry
+				break
+			}
+		}
+	}
+
 	if len(ctxt.Shlibs) > 0 {
 		// We might have overwritten some functions above (this tends to happen for the
 		// autogenerated type equality/hashing functions) and we don't want to generated
@@ -76,7 +76,8 @@ func (ctxt *Link) computeTLSOffset() {
 		obj.Hnetbsd,
 		obj.Hopenbsd,
 		obj.Hdragonfly,
-		obj.Hsolaris:
+		obj.Hsolaris,
+		obj.Hnone:
 		if obj.GOOS == "android" {
 			switch ctxt.Arch.Family {
 			case sys.AMD64:
@@ -53,8 +53,8 @@ func elfreloc1(ctxt *ld.Link, r *ld.Reloc, sectoff int64) int {
 }
 
 func elfsetupplt(ctxt *ld.Link) {
-	log.Printf("elfsetuplt")
-	return
+	// Go code is never linked against shared libraries on riscv,
+	// so there are no PLT entries to set up.
 }
 
 func machoreloc1(s *ld.Symbol, r *ld.Reloc, sectoff int64) int {
@@ -144,30 +144,43 @@ func gentramp(tramp, target *ld.Symbol, offset int64) {
 
 func archreloc(ctxt *ld.Link, r *ld.Reloc, s *ld.Symbol, val *int64) int {
 	switch r.Type {
-	case obj.R_RISCV_PCREL_ITYPE, obj.R_RISCV_PCREL_STYPE, obj.R_CALLRISCV2:
-		pc := s.Value + int64(r.Off)
-		off := ld.Symaddr(r.Sym) + r.Add - pc
+	case obj.R_RISCV_PCREL_ITYPE, obj.R_RISCV_PCREL_STYPE, obj.R_CALLRISCV2, obj.R_RISCV_TLS_LE:
+		var off int64
+		if r.Type == obj.R_RISCV_TLS_LE {
+			if ld.Headtype != obj.Hlinux && ld.Headtype != obj.Hfreebsd && ld.Headtype != obj.Hnone {
+				ld.Errorf(s, "TLS reloc on unsupported OS %v", ld.Headtype)
+			}
+			// On both Linux and FreeBSD the thread pointer points
+			// at the start of the TLS block, so a thread-local
+			// symbol is found at its offset within .tbss.
+			// Bare-metal programs never use cgo and so never
+			// touch TLS, but they are laid out the same way.
+			off = r.Sym.Value + r.Add
+		} else {
+			pc := s.Value + int64(r.Off)
+			off = ld.Symaddr(r.Sym) + r.Add - pc
+		}
 
-		// Generate AUIPC and second instruction immediates.
+		// Generate AUIPC (or LUI) and second instruction immediates.
 		low, high, err := riscv.Split32BitImmediate(off)
 		if err != nil {
-			ld.Errorf(s, "R_RISCV_PCREL_ relocation does not fit in 32-bits: %d", off)
+			ld.Errorf(s, "%v relocation does not fit in 32-bits: %d", r.Type, off)
 			return 0
 		}
 
 		auipcImm, err := riscv.EncodeUImmediate(high)
 		if err != nil {
-			ld.Errorf(s, "cannot encode R_RISCV_PCREL_ AUIPC relocation offset for %s: %v", r.Sym.Name, err)
+			ld.Errorf(s, "cannot encode %v U-type instruction relocation offset for %s: %v", r.Type, r.Sym.Name, err)
 			return 0
 		}
 
 		var secondImm, secondImmMask int64
 		switch r.Type {
-		case obj.R_RISCV_PCREL_ITYPE, obj.R_CALLRISCV2:
+		case obj.R_RISCV_PCREL_ITYPE, obj.R_CALLRISCV2, obj.R_RISCV_TLS_LE:
 			secondImmMask = riscv.ITypeImmMask
 			secondImm, err = riscv.EncodeIImmediate(low)
 			if err != nil {
-				ld.Errorf(s, "cannot encode R_RISCV_PCREL_ITYPE I-type instruction relocation offset for %s: %v", r.Sym.Name, err)
+				ld.Errorf(s, "cannot encode %v I-type instruction relocation offset for %s: %v", r.Type, r.Sym.Name, err)
 				return 0
 			}
 		case obj.R_RISCV_PCREL_STYPE:
@@ -320,7 +333,8 @@ func asmb(ctxt *ld.Link) {
 		obj.Hfreebsd,
 		obj.Hnetbsd,
 		obj.Hopenbsd,
-		obj.Hnacl:
+		obj.Hnacl,
+		obj.Hnone:
 		ld.Asmbelf(ctxt, int64(symo))
 	}
 
@@ -68,6 +68,7 @@ const (
 const (
 	// From GNU GCC REGISTER_NAMES
 	// https://github.com/riscv/riscv-gnu-toolchain/blob/master/gcc/gcc/config/riscv/riscv.h#L858
+	// As in the psABI, x0-x31 are DWARF registers 0-31 and f0-f31 are 32-63.
 	DWARFREGSP = 2
 	DWARFREGLR = 1
 )
@@ -67,11 +67,10 @@ func linkarchinit() {
 	ld.Thearch.Append32 = ld.Append32l
 	ld.Thearch.Append64 = ld.Append64l
 
-	ld.Thearch.Linuxdynld = "/lib/ld.so.1"
+	ld.Thearch.Linuxdynld = "/lib/ld-linux-riscv64-lp64d.so.1"
+	ld.Thearch.Freebsddynld = "/libexec/ld-elf.so.1"
 
-	// TODO: FreeBSD and NetBSD have RISCV ports, but we don't support
-	// them yet.
-	ld.Thearch.Freebsddynld = "XXX"
+	// TODO: NetBSD has a RISCV port, but we don't support it yet.
 	ld.Thearch.Netbsddynld = "XXX"
 
 	ld.Thearch.Openbsddynld = "XXX"
@@ -100,7 +99,8 @@ func archinit(ctxt *ld.Link) {
 	default:
 		ld.Exitf("unknown -H option: %v", ld.Headtype)
 
-	case obj.Hlinux: /* riscv elf */
+	case obj.Hlinux, /* riscv elf */
+		obj.Hfreebsd:
 		ld.Elfinit(ctxt)
 		ld.HEADR = ld.ELFRESERVE
 		if *ld.FlagTextAddr == -1 {
@@ -112,6 +112,25 @@ func archinit(ctxt *ld.Link) {
 		if *ld.FlagRound == -1 {
 			*ld.FlagRound = 0x1000
 		}
+
+	case obj.Hnone: /* riscv elf, bare metal */
+		ld.Elfinit(ctxt)
+		ld.HEADR = ld.ELFRESERVE
+		// Boot ROMs (including QEMU's virt machine with -bios none)
+		// jump to the start of RAM, so the image is linked there and
+		// the ELF headers are left out of the loaded segment. SBI
+		// firmware such as OpenSBI occupies that spot instead and
+		// enters the kernel at 0x80200000, in supervisor mode; link
+		// with -E _rt0_riscv_none_sbi -T 0x80200000 to run under it.
+		if *ld.FlagTextAddr == -1 {
+			*ld.FlagTextAddr = 0x80000000
+		}
+		if *ld.FlagDataAddr == -1 {
+			*ld.FlagDataAddr = 0
+		}
+		if *ld.FlagRound == -1 {
+			*ld.FlagRound = 0x1000
+		}
 	}
 
 	if *ld.FlagDataAddr != 0 && *ld.FlagRound != 0 {
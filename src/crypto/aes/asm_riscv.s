@@ -0,0 +1,95 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// The AES64 instructions of the Zkne and Zknd extensions each compute
+// one half of a round: given the state as two doublewords {rs2:rs1},
+// they produce the doubleword that replaces rs1. Swapping the sources
+// produces the other half.
+//
+// src and dst need not be aligned; misaligned doubleword accesses are
+// completed by the hardware or by the kernel.
+
+// func encryptBlockAsm(nr int, xk *uint32, dst, src *byte)
+TEXT ·encryptBlockAsm(SB),NOSPLIT,$0
+	MOV	nr+0(FP), A0
+	MOV	xk+8(FP), A1
+	MOV	dst+16(FP), A2
+	MOV	src+24(FP), A3
+	MOV	0(A3), T0
+	MOV	8(A3), T1
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T0
+	XOR	A5, T1
+	ADD	$16, A1
+	ADD	$-1, A0
+loop:
+	AES64ESM	T1, T0, T2
+	AES64ESM	T0, T1, T3
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T2, T0
+	XOR	A5, T3, T1
+	ADD	$16, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+	AES64ES	T1, T0, T2
+	AES64ES	T0, T1, T3
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T2
+	XOR	A5, T3
+	MOV	T2, 0(A2)
+	MOV	T3, 8(A2)
+	RET
+
+// func decryptBlockAsm(nr int, xk *uint32, dst, src *byte)
+//
+// xk is the key schedule for the equivalent inverse cipher, so each
+// round is InvShiftRows, InvSubBytes and InvMixColumns followed by
+// AddRoundKey, matching AES64DSM.
+TEXT ·decryptBlockAsm(SB),NOSPLIT,$0
+	MOV	nr+0(FP), A0
+	MOV	xk+8(FP), A1
+	MOV	dst+16(FP), A2
+	MOV	src+24(FP), A3
+	MOV	0(A3), T0
+	MOV	8(A3), T1
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T0
+	XOR	A5, T1
+	ADD	$16, A1
+	ADD	$-1, A0
+loop:
+	AES64DSM	T1, T0, T2
+	AES64DSM	T0, T1, T3
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T2, T0
+	XOR	A5, T3, T1
+	ADD	$16, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+	AES64DS	T1, T0, T2
+	AES64DS	T0, T1, T3
+	MOV	0(A1), A4
+	MOV	8(A1), A5
+	XOR	A4, T2
+	XOR	A5, T3
+	MOV	T2, 0(A2)
+	MOV	T3, 8(A2)
+	RET
+
+// func hasZkn() bool
+TEXT ·hasZkn(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zkn
+	MOV	$1, T0
+#else
+	MOV	ZERO, T0
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
@@ -0,0 +1,76 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package aes
+
+import (
+	"crypto/cipher"
+	"internal/cpu"
+)
+
+// defined in asm_riscv.s
+
+// hasZkn reports whether the code was built for a CPU with the
+// scalar cryptography extension, which provides the AES64 instructions.
+func hasZkn() bool
+
+func encryptBlockAsm(nr int, xk *uint32, dst, src *byte)
+func decryptBlockAsm(nr int, xk *uint32, dst, src *byte)
+
+type aesCipherAsm struct {
+	aesCipher
+}
+
+var useAsm = hasZkn() || cpu.RISCV.HasZkne && cpu.RISCV.HasZknd
+
+func newCipher(key []byte) (cipher.Block, error) {
+	if !useAsm {
+		return newCipherCT(key), nil
+	}
+	n := len(key) + 28
+	c := aesCipherAsm{aesCipher{make([]uint32, n), make([]uint32, n)}}
+	expandKey(key, c.enc, c.dec)
+	return &c, nil
+}
+
+func (c *aesCipherAsm) BlockSize() int { return BlockSize }
+
+func (c *aesCipherAsm) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	encryptBlockAsm(len(c.enc)/4-1, &c.enc[0], &dst[0], &src[0])
+}
+
+func (c *aesCipherAsm) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	decryptBlockAsm(len(c.dec)/4-1, &c.dec[0], &dst[0], &src[0])
+}
+
+// expandKey is used by BenchmarkExpand to ensure that the key layout
+// used by the assembly is what is benchmarked when it is available.
+//
+// The AES64 instructions hold the state as two little-endian doublewords
+// of state bytes, so the round keys are stored with the bytes of each
+// word in memory order rather than as the big-endian words of
+// expandKeyGo.
+func expandKey(key []byte, enc, dec []uint32) {
+	expandKeyGo(key, enc, dec)
+	if useAsm {
+		for i, w := range enc {
+			enc[i] = w>>24 | w>>8&0xff00 | w<<8&0xff0000 | w<<24
+		}
+		for i, w := range dec {
+			dec[i] = w>>24 | w>>8&0xff00 | w<<8&0xff0000 | w<<24
+		}
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This is a constant-time implementation of AES for RISC-V processors
+// without the scalar cryptography extension. It is derived from the
+// bitsliced "aes_ct" implementation in BearSSL by Thomas Pornin.
+//
+// The table-driven code in block.go indexes its tables with key- and
+// data-dependent values, which leaks through the data cache. Here the
+// state is held as eight 32-bit words, word i holding bit i of every
+// state byte, and all of AES is computed with AND, XOR, NOT, shifts and
+// rotations. The S-box is the circuit of Boyar and Peralta.
+//
+// The bitsliced words have room for two blocks; Encrypt and Decrypt
+// process one, so both lanes hold the same block.
+
+package aes
+
+type aesCipherCT struct {
+	nr int
+	sk []uint32 // (nr+1) round keys of 8 words each, bitsliced
+}
+
+// newCipherCT creates and returns a new cipher.Block
+// that runs in time independent of the key and data.
+func newCipherCT(key []byte) *aesCipherCT {
+	nr := len(key)/4 + 6
+	c := &aesCipherCT{nr, make([]uint32, (nr+1)*8)}
+	expandKeyCT(key, c.sk)
+	return c
+}
+
+func (c *aesCipherCT) BlockSize() int { return BlockSize }
+
+func (c *aesCipherCT) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	var q [8]uint32
+	loadCT(&q, src)
+	sk := c.sk
+	addRoundKeyCT(&q, sk)
+	for r := 1; r < c.nr; r++ {
+		sboxCT(&q)
+		shiftRowsCT(&q)
+		mixColumnsCT(&q)
+		addRoundKeyCT(&q, sk[r*8:])
+	}
+	sboxCT(&q)
+	shiftRowsCT(&q)
+	addRoundKeyCT(&q, sk[c.nr*8:])
+	storeCT(dst, &q)
+}
+
+func (c *aesCipherCT) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	var q [8]uint32
+	loadCT(&q, src)
+	sk := c.sk
+	addRoundKeyCT(&q, sk[c.nr*8:])
+	for r := c.nr - 1; r > 0; r-- {
+		invShiftRowsCT(&q)
+		invSboxCT(&q)
+		addRoundKeyCT(&q, sk[r*8:])
+		invMixColumnsCT(&q)
+	}
+	invShiftRowsCT(&q)
+	invSboxCT(&q)
+	addRoundKeyCT(&q, sk)
+	storeCT(dst, &q)
+}
+
+// expandKeyCT computes the round keys of key in bitsliced form.
+// It uses sboxCT for SubWord, so that it too runs in constant time.
+func expandKeyCT(key []byte, sk []uint32) {
+	nk := len(key) / 4
+	n := len(sk) / 2
+	w := make([]uint32, n)
+	for i := 0; i < nk; i++ {
+		w[i] = uint32(key[4*i]) | uint32(key[4*i+1])<<8 | uint32(key[4*i+2])<<16 | uint32(key[4*i+3])<<24
+	}
+	// The words are little-endian, so RotWord is a right rotation.
+	for i := nk; i < n; i++ {
+		t := w[i-1]
+		if i%nk == 0 {
+			t = subWordCT(t>>8|t<<24) ^ uint32(powx[i/nk-1])
+		} else if nk > 6 && i%nk == 4 {
+			t = subWordCT(t)
+		}
+		w[i] = w[i-nk] ^ t
+	}
+	var q [8]uint32
+	for i := 0; i < n; i += 4 {
+		for j := 0; j < 4; j++ {
+			q[2*j] = w[i+j]
+			q[2*j+1] = w[i+j]
+		}
+		orthoCT(&q)
+		copy(sk[2*i:], q[:])
+	}
+}
+
+func subWordCT(x uint32) uint32 {
+	q := [8]uint32{x, x, x, x, x, x, x, x}
+	orthoCT(&q)
+	sboxCT(&q)
+	orthoCT(&q)
+	return q[0]
+}
+
+// loadCT loads a block into both lanes of q.
+func loadCT(q *[8]uint32, b []byte) {
+	_ = b[15] // bounds check hint to compiler
+	for i := 0; i < 4; i++ {
+		w := uint32(b[4*i]) | uint32(b[4*i+1])<<8 | uint32(b[4*i+2])<<16 | uint32(b[4*i+3])<<24
+		q[2*i] = w
+		q[2*i+1] = w
+	}
+	orthoCT(q)
+}
+
+func storeCT(b []byte, q *[8]uint32) {
+	orthoCT(q)
+	_ = b[15] // bounds check hint to compiler
+	for i := 0; i < 4; i++ {
+		w := q[2*i]
+		b[4*i] = byte(w)
+		b[4*i+1] = byte(w >> 8)
+		b[4*i+2] = byte(w >> 16)
+		b[4*i+3] = byte(w >> 24)
+	}
+}
+
+// orthoCT converts between the byte-oriented and the bitsliced form.
+// It is its own inverse. In bitsliced form, bit 8*r+2*c+l of q[i] is
+// bit i of the byte in row r and column c of the block in lane l.
+func orthoCT(q *[8]uint32) {
+	swapCT(0x55555555, 0xaaaaaaaa, 1, &q[0], &q[1])
+	swapCT(0x55555555, 0xaaaaaaaa, 1, &q[2], &q[3])
+	swapCT(0x55555555, 0xaaaaaaaa, 1, &q[4], &q[5])
+	swapCT(0x55555555, 0xaaaaaaaa, 1, &q[6], &q[7])
+
+	swapCT(0x33333333, 0xcccccccc, 2, &q[0], &q[2])
+	swapCT(0x33333333, 0xcccccccc, 2, &q[1], &q[3])
+	swapCT(0x33333333, 0xcccccccc, 2, &q[4], &q[6])
+	swapCT(0x33333333, 0xcccccccc, 2, &q[5], &q[7])
+
+	swapCT(0x0f0f0f0f, 0xf0f0f0f0, 4, &q[0], &q[4])
+	swapCT(0x0f0f0f0f, 0xf0f0f0f0, 4, &q[1], &q[5])
+	swapCT(0x0f0f0f0f, 0xf0f0f0f0, 4, &q[2], &q[6])
+	swapCT(0x0f0f0f0f, 0xf0f0f0f0, 4, &q[3], &q[7])
+}
+
+func swapCT(cl, ch uint32, s uint, x, y *uint32) {
+	a, b := *x, *y
+	*x = a&cl | (b&cl)<<s
+	*y = (a&ch)>>s | b&ch
+}
+
+func addRoundKeyCT(q *[8]uint32, sk []uint32) {
+	_ = sk[7] // bounds check hint to compiler
+	for i := range q {
+		q[i] ^= sk[i]
+	}
+}
+
+// sboxCT applies the S-box to every byte of q.
+func sboxCT(q *[8]uint32) {
+	x0, x1, x2, x3 := q[7], q[6], q[5], q[4]
+	x4, x5, x6, x7 := q[3], q[2], q[1], q[0]
+
+	// Top linear transformation.
+	y14 := x3 ^ x5
+	y13 := x0 ^ x6
+	y9 := x0 ^ x3
+	y8 := x0 ^ x5
+	t0 := x1 ^ x2
+	y1 := t0 ^ x7
+	y4 := y1 ^ x3
+	y12 := y13 ^ y14
+	y2 := y1 ^ x0
+	y5 := y1 ^ x6
+	y3 := y5 ^ y8
+	t1 := x4 ^ y12
+	y15 := t1 ^ x5
+	y20 := t1 ^ x1
+	y6 := y15 ^ x7
+	y10 := y15 ^ t0
+	y11 := y20 ^ y9
+	y7 := x7 ^ y11
+	y17 := y10 ^ y11
+	y19 := y10 ^ y8
+	y16 := t0 ^ y11
+	y21 := y13 ^ y16
+	y18 := x0 ^ y16
+
+	// Non-linear section.
+	t2 := y12 & y15
+	t3 := y3 & y6
+	t4 := t3 ^ t2
+	t5 := y4 & x7
+	t6 := t5 ^ t2
+	t7 := y13 & y16
+	t8 := y5 & y1
+	t9 := t8 ^ t7
+	t10 := y2 & y7
+	t11 := t10 ^ t7
+	t12 := y9 & y11
+	t13 := y14 & y17
+	t14 := t13 ^ t12
+	t15 := y8 & y10
+	t16 := t15 ^ t12
+	t17 := t4 ^ t14
+	t18 := t6 ^ t16
+	t19 := t9 ^ t14
+	t20 := t11 ^ t16
+	t21 := t17 ^ y20
+	t22 := t18 ^ y19
+	t23 := t19 ^ y21
+	t24 := t20 ^ y18
+
+	t25 := t21 ^ t22
+	t26 := t21 & t23
+	t27 := t24 ^ t26
+	t28 := t25 & t27
+	t29 := t28 ^ t22
+	t30 := t23 ^ t24
+	t31 := t22 ^ t26
+	t32 := t31 & t30
+	t33 := t32 ^ t24
+	t34 := t23 ^ t33
+	t35 := t27 ^ t33
+	t36 := t24 & t35
+	t37 := t36 ^ t34
+	t38 := t27 ^ t36
+	t39 := t29 & t38
+	t40 := t25 ^ t39
+
+	t41 := t40 ^ t37
+	t42 := t29 ^ t33
+	t43 := t29 ^ t40
+	t44 := t33 ^ t37
+	t45 := t42 ^ t41
+	z0 := t44 & y15
+	z1 := t37 & y6
+	z2 := t33 & x7
+	z3 := t43 & y16
+	z4 := t40 & y1
+	z5 := t29 & y7
+	z6 := t42 & y11
+	z7 := t45 & y17
+	z8 := t41 & y10
+	z9 := t44 & y12
+	z10 := t37 & y3
+	z11 := t33 & y4
+	z12 := t43 & y13
+	z13 := t40 & y5
+	z14 := t29 & y2
+	z15 := t42 & y9
+	z16 := t45 & y14
+	z17 := t41 & y8
+
+	// Bottom linear transformation.
+	t46 := z15 ^ z16
+	t47 := z10 ^ z11
+	t48 := z5 ^ z13
+	t49 := z9 ^ z10
+	t50 := z2 ^ z12
+	t51 := z2 ^ z5
+	t52 := z7 ^ z8
+	t53 := z0 ^ z3
+	t54 := z6 ^ z7
+	t55 := z16 ^ z17
+	t56 := z12 ^ t48
+	t57 := t50 ^ t53
+	t58 := z4 ^ t46
+	t59 := z3 ^ t54
+	t60 := t46 ^ t57
+	t61 := z14 ^ t57
+	t62 := t52 ^ t58
+	t63 := t49 ^ t58
+	t64 := z4 ^ t59
+	t65 := t61 ^ t62
+	t66 := z1 ^ t63
+	s0 := t59 ^ t63
+	s6 := t56 ^ ^t62
+	s7 := t48 ^ ^t60
+	t67 := t64 ^ t65
+	s3 := t53 ^ t66
+	s4 := t51 ^ t66
+	s5 := t47 ^ t65
+	s1 := t64 ^ ^s3
+	s2 := t55 ^ ^t67
+
+	q[7], q[6], q[5], q[4] = s0, s1, s2, s3
+	q[3], q[2], q[1], q[0] = s4, s5, s6, s7
+}
+
+// invSboxCT applies the inverse S-box to every byte of q. The S-box is
+// an affine map A after inversion, so its inverse is A⁻¹ followed by
+// inversion, computed here as A⁻¹ ∘ S ∘ A⁻¹.
+func invSboxCT(q *[8]uint32) {
+	invAffineCT(q)
+	sboxCT(q)
+	invAffineCT(q)
+}
+
+func invAffineCT(q *[8]uint32) {
+	q0, q1, q2, q3 := ^q[0], ^q[1], q[2], q[3]
+	q4, q5, q6, q7 := q[4], ^q[5], ^q[6], q[7]
+	q[7] = q1 ^ q4 ^ q6
+	q[6] = q0 ^ q3 ^ q5
+	q[5] = q7 ^ q2 ^ q4
+	q[4] = q6 ^ q1 ^ q3
+	q[3] = q5 ^ q0 ^ q2
+	q[2] = q4 ^ q7 ^ q1
+	q[1] = q3 ^ q6 ^ q0
+	q[0] = q2 ^ q5 ^ q7
+}
+
+// shiftRowsCT rotates row r right by r columns, that is, by 2*r bits
+// within its byte of each word.
+func shiftRowsCT(q *[8]uint32) {
+	for i, x := range q {
+		q[i] = x&0x000000ff |
+			(x&0x0000fc00)>>2 | (x&0x00000300)<<6 |
+			(x&0x00f00000)>>4 | (x&0x000f0000)<<4 |
+			(x&0xc0000000)>>6 | (x&0x3f000000)<<2
+	}
+}
+
+func invShiftRowsCT(q *[8]uint32) {
+	for i, x := range q {
+		q[i] = x&0x000000ff |
+			(x&0x00003f00)<<2 | (x&0x0000c000)>>6 |
+			(x&0x000f0000)<<4 | (x&0x00f00000)>>4 |
+			(x&0x03000000)<<6 | (x&0xfc000000)>>2
+	}
+}
+
+func rotr8(x uint32) uint32  { return x>>8 | x<<24 }
+func rotr16(x uint32) uint32 { return x>>16 | x<<16 }
+
+// mixColumnsCT computes, for each row r of each column a,
+// 2·a[r] ⊕ 3·a[r+1] ⊕ a[r+2] ⊕ a[r+3]
+// = 2·(a[r] ⊕ a[r+1]) ⊕ a[r+1] ⊕ (a[r+2] ⊕ a[r+3]).
+// Rotating a word right by 8 bits moves row r+1 into row r, and
+// multiplication by 2 maps bit i to bit i+1, reducing bit 7 into
+// bits 0, 1, 3 and 4.
+func mixColumnsCT(q *[8]uint32) {
+	q0, q1, q2, q3, q4, q5, q6, q7 := q[0], q[1], q[2], q[3], q[4], q[5], q[6], q[7]
+	r0, r1, r2, r3 := rotr8(q0), rotr8(q1), rotr8(q2), rotr8(q3)
+	r4, r5, r6, r7 := rotr8(q4), rotr8(q5), rotr8(q6), rotr8(q7)
+
+	q[0] = q7 ^ r7 ^ r0 ^ rotr16(q0^r0)
+	q[1] = q0 ^ r0 ^ q7 ^ r7 ^ r1 ^ rotr16(q1^r1)
+	q[2] = q1 ^ r1 ^ r2 ^ rotr16(q2^r2)
+	q[3] = q2 ^ r2 ^ q7 ^ r7 ^ r3 ^ rotr16(q3^r3)
+	q[4] = q3 ^ r3 ^ q7 ^ r7 ^ r4 ^ rotr16(q4^r4)
+	q[5] = q4 ^ r4 ^ r5 ^ rotr16(q5^r5)
+	q[6] = q5 ^ r5 ^ r6 ^ rotr16(q6^r6)
+	q[7] = q6 ^ r6 ^ r7 ^ rotr16(q7^r7)
+}
+
+// invMixColumnsCT uses the factorization of the InvMixColumns matrix
+// as MixColumns times the matrix with rows (5 0 4 0), (0 5 0 4),
+// (4 0 5 0), (0 4 0 5): each a[r] is first replaced by
+// a[r] ⊕ 4·(a[r] ⊕ a[r+2]).
+func invMixColumnsCT(q *[8]uint32) {
+	var t [8]uint32
+	for i, x := range q {
+		t[i] = x ^ rotr16(x)
+	}
+	for k := 0; k < 2; k++ {
+		t7 := t[7]
+		t[7], t[6], t[5] = t[6], t[5], t[4]
+		t[4] = t[3] ^ t7
+		t[3] = t[2] ^ t7
+		t[2] = t[1]
+		t[1] = t[0] ^ t7
+		t[0] = t7
+	}
+	for i := range q {
+		q[i] ^= t[i]
+	}
+	mixColumnsCT(q)
+}
@@ -0,0 +1,40 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha256
+
+import "internal/cpu"
+
+// hasZkn reports whether the code was built for a CPU with the
+// scalar cryptography extension, which provides the SHA256SIG and
+// SHA256SUM instructions.
+func hasZkn() bool
+
+// hasZbb reports whether the code was built for a CPU with the
+// rotate and ANDN instructions of Zbb (or Zbkb, part of Zkn).
+func hasZbb() bool
+
+// blockZkn needs ANDN as well as the SHA instructions. Zkn includes it
+// as part of Zbkb, but Zknh alone does not.
+var (
+	useZkn = hasZkn() || cpu.RISCV.HasZknh && (cpu.RISCV.HasZbb || cpu.RISCV.HasZbkb)
+	useZbb = hasZbb() || cpu.RISCV.HasZbb || cpu.RISCV.HasZbkb
+)
+
+//go:noescape
+func blockZkn(dig *digest, p []byte)
+
+//go:noescape
+func blockZbb(dig *digest, p []byte)
+
+func block(dig *digest, p []byte) {
+	switch {
+	case useZkn:
+		blockZkn(dig, p)
+	case useZbb:
+		blockZbb(dig, p)
+	default:
+		blockGeneric(dig, p)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// SHA256 block routines for riscv. See sha256block.go for the algorithm.
+//
+// blockZkn uses the SHA256SIG and SHA256SUM instructions from the Zknh
+// extension. blockZbb computes the same functions with the rotates of
+// Zbb. Both use ANDN for Ch, so neither has data-dependent branches or
+// table lookups.
+//
+// The working variables a..h live in A3, A4, A5, A6, A7, S1, S2 and S3,
+// and are renamed rather than moved between rounds. They hold 32-bit
+// values in 64-bit registers: the upper halves are ignored until the
+// ADDWs that produce d and h. The 16-word message schedule is kept on
+// the stack at 8(SP), S5 points at the round constants for the current
+// 16 rounds, A1 at the current block and A2 at the end of p.
+
+#define W(index) (8+((index)&15)*4)(SP)
+
+// Wt = Mt; for 0 <= t <= 15, leaving Wt in T0.
+// p need not be aligned, so assemble the big-endian word a byte at a time.
+#define LOAD(index) \
+	MOVBU	((index)*4)(A1), T0; \
+	MOVBU	((index)*4+1)(A1), T1; \
+	MOVBU	((index)*4+2)(A1), T2; \
+	MOVBU	((index)*4+3)(A1), T3; \
+	SLL	$24, T0; \
+	SLL	$16, T1; \
+	SLL	$8, T2; \
+	OR	T1, T0; \
+	OR	T3, T2; \
+	OR	T2, T0; \
+	MOVW	T0, W(index)
+
+// Wt = SIGMA1(Wt-2) + Wt-7 + SIGMA0(Wt-15) + Wt-16; for 16 <= t <= 63,
+// leaving Wt in T0.
+#define SCHEDULEZKN(index) \
+	MOVWU	W((index)+14), T0; \
+	MOVWU	W((index)+1), T1; \
+	MOVWU	W((index)+9), T2; \
+	MOVWU	W(index), T3; \
+	SHA256SIG1	T0, T0; \
+	SHA256SIG0	T1, T1; \
+	ADD	T2, T3; \
+	ADD	T1, T0; \
+	ADD	T3, T0; \
+	MOVW	T0, W(index)
+
+// T1 = h + BIGSIGMA1(e) + Ch(e, f, g) + Kt + Wt
+// T2 = BIGSIGMA0(a) + Maj(a, b, c)
+// d += T1; h = T1 + T2
+// Wt is passed in T0.
+#define ROUNDZKN(index, a, b, c, d, e, f, g, h) \
+	MOVWU	((index)*4)(S5), T1; \
+	ADD	T0, h; \
+	SHA256SUM1	e, T2; \
+	ADD	T1, h; \
+	AND	e, f, T3; \
+	ANDN	e, g, T4; \
+	ADD	T2, h; \
+	OR	T4, T3; \
+	SHA256SUM0	a, T2; \
+	ADD	T3, h; \
+	OR	a, b, T1; \
+	AND	a, b, T4; \
+	AND	c, T1; \
+	ADDW	h, d; \
+	OR	T4, T1; \
+	ADD	T2, h; \
+	ADDW	T1, h
+
+//   SIGMA0(x) = ROTR(7,x) XOR ROTR(18,x) XOR SHR(3,x)
+//   SIGMA1(x) = ROTR(17,x) XOR ROTR(19,x) XOR SHR(10,x)
+// MOVWU zero-extends the schedule words, so SRL acts as a 32-bit shift.
+#define SCHEDULEZBB(index) \
+	MOVWU	W((index)+14), T0; \
+	MOVWU	W((index)+1), T1; \
+	RORIW	$17, T0, T2; \
+	RORIW	$19, T0, T3; \
+	SRL	$10, T0, T0; \
+	XOR	T3, T2; \
+	XOR	T2, T0; \
+	RORIW	$7, T1, T2; \
+	RORIW	$18, T1, T3; \
+	SRL	$3, T1, T1; \
+	XOR	T3, T2; \
+	XOR	T2, T1; \
+	MOVWU	W((index)+9), T2; \
+	MOVWU	W(index), T3; \
+	ADD	T1, T0; \
+	ADD	T2, T3; \
+	ADD	T3, T0; \
+	MOVW	T0, W(index)
+
+//   BIGSIGMA0(x) = ROTR(2,x) XOR ROTR(13,x) XOR ROTR(22,x)
+//   BIGSIGMA1(x) = ROTR(6,x) XOR ROTR(11,x) XOR ROTR(25,x)
+#define ROUNDZBB(index, a, b, c, d, e, f, g, h) \
+	MOVWU	((index)*4)(S5), T1; \
+	ADD	T0, h; \
+	RORIW	$6, e, T2; \
+	RORIW	$11, e, T3; \
+	ADD	T1, h; \
+	RORIW	$25, e, T4; \
+	XOR	T3, T2; \
+	AND	e, f, T3; \
+	XOR	T4, T2; \
+	ANDN	e, g, T4; \
+	ADD	T2, h; \
+	OR	T4, T3; \
+	RORIW	$2, a, T2; \
+	ADD	T3, h; \
+	RORIW	$13, a, T3; \
+	RORIW	$22, a, T4; \
+	XOR	T3, T2; \
+	OR	a, b, T1; \
+	XOR	T4, T2; \
+	AND	a, b, T4; \
+	AND	c, T1; \
+	ADDW	h, d; \
+	OR	T4, T1; \
+	ADD	T2, h; \
+	ADDW	T1, h
+
+// Hi += a..h for the digest at A0.
+#define UPDATEHASH(off, r) \
+	MOVWU	(off)(A0), T0; \
+	ADDW	T0, r; \
+	MOVW	r, (off)(A0)
+
+// func blockZkn(dig *digest, p []byte)
+TEXT ·blockZkn(SB),0,$64-32
+	MOV	dig+0(FP), A0
+	MOV	p_base+8(FP), A1
+	MOV	p_len+16(FP), A2
+	AND	$~63, A2
+	BEQ	A2, ZERO, end
+	ADD	A1, A2
+
+	MOVWU	0(A0), A3
+	MOVWU	4(A0), A4
+	MOVWU	8(A0), A5
+	MOVWU	12(A0), A6
+	MOVWU	16(A0), A7
+	MOVWU	20(A0), S1
+	MOVWU	24(A0), S2
+	MOVWU	28(A0), S3
+
+loop:
+	MOV	·_K(SB), S5
+
+	LOAD(0)
+	ROUNDZKN(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(1)
+	ROUNDZKN(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(2)
+	ROUNDZKN(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(3)
+	ROUNDZKN(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(4)
+	ROUNDZKN(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(5)
+	ROUNDZKN(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(6)
+	ROUNDZKN(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(7)
+	ROUNDZKN(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	LOAD(8)
+	ROUNDZKN(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(9)
+	ROUNDZKN(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(10)
+	ROUNDZKN(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(11)
+	ROUNDZKN(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(12)
+	ROUNDZKN(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(13)
+	ROUNDZKN(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(14)
+	ROUNDZKN(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(15)
+	ROUNDZKN(15, A4, A5, A6, A7, S1, S2, S3, A3)
+
+	MOV	$3, S6
+schedule:
+	ADD	$64, S5
+	SCHEDULEZKN(0)
+	ROUNDZKN(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZKN(1)
+	ROUNDZKN(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZKN(2)
+	ROUNDZKN(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZKN(3)
+	ROUNDZKN(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZKN(4)
+	ROUNDZKN(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZKN(5)
+	ROUNDZKN(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZKN(6)
+	ROUNDZKN(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZKN(7)
+	ROUNDZKN(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	SCHEDULEZKN(8)
+	ROUNDZKN(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZKN(9)
+	ROUNDZKN(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZKN(10)
+	ROUNDZKN(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZKN(11)
+	ROUNDZKN(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZKN(12)
+	ROUNDZKN(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZKN(13)
+	ROUNDZKN(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZKN(14)
+	ROUNDZKN(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZKN(15)
+	ROUNDZKN(15, A4, A5, A6, A7, S1, S2, S3, A3)
+	ADD	$-1, S6
+	BNE	S6, ZERO, schedule
+
+	UPDATEHASH(0, A3)
+	UPDATEHASH(4, A4)
+	UPDATEHASH(8, A5)
+	UPDATEHASH(12, A6)
+	UPDATEHASH(16, A7)
+	UPDATEHASH(20, S1)
+	UPDATEHASH(24, S2)
+	UPDATEHASH(28, S3)
+
+	ADD	$64, A1
+	BLTU	A1, A2, loop
+
+end:
+	RET
+
+// func blockZbb(dig *digest, p []byte)
+TEXT ·blockZbb(SB),0,$64-32
+	MOV	dig+0(FP), A0
+	MOV	p_base+8(FP), A1
+	MOV	p_len+16(FP), A2
+	AND	$~63, A2
+	BEQ	A2, ZERO, end
+	ADD	A1, A2
+
+	MOVWU	0(A0), A3
+	MOVWU	4(A0), A4
+	MOVWU	8(A0), A5
+	MOVWU	12(A0), A6
+	MOVWU	16(A0), A7
+	MOVWU	20(A0), S1
+	MOVWU	24(A0), S2
+	MOVWU	28(A0), S3
+
+loop:
+	MOV	·_K(SB), S5
+
+	LOAD(0)
+	ROUNDZBB(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(1)
+	ROUNDZBB(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(2)
+	ROUNDZBB(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(3)
+	ROUNDZBB(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(4)
+	ROUNDZBB(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(5)
+	ROUNDZBB(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(6)
+	ROUNDZBB(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(7)
+	ROUNDZBB(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	LOAD(8)
+	ROUNDZBB(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(9)
+	ROUNDZBB(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(10)
+	ROUNDZBB(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(11)
+	ROUNDZBB(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(12)
+	ROUNDZBB(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(13)
+	ROUNDZBB(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(14)
+	ROUNDZBB(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(15)
+	ROUNDZBB(15, A4, A5, A6, A7, S1, S2, S3, A3)
+
+	MOV	$3, S6
+schedule:
+	ADD	$64, S5
+	SCHEDULEZBB(0)
+	ROUNDZBB(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZBB(1)
+	ROUNDZBB(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZBB(2)
+	ROUNDZBB(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZBB(3)
+	ROUNDZBB(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZBB(4)
+	ROUNDZBB(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZBB(5)
+	ROUNDZBB(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZBB(6)
+	ROUNDZBB(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZBB(7)
+	ROUNDZBB(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	SCHEDULEZBB(8)
+	ROUNDZBB(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZBB(9)
+	ROUNDZBB(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZBB(10)
+	ROUNDZBB(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZBB(11)
+	ROUNDZBB(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZBB(12)
+	ROUNDZBB(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZBB(13)
+	ROUNDZBB(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZBB(14)
+	ROUNDZBB(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZBB(15)
+	ROUNDZBB(15, A4, A5, A6, A7, S1, S2, S3, A3)
+	ADD	$-1, S6
+	BNE	S6, ZERO, schedule
+
+	UPDATEHASH(0, A3)
+	UPDATEHASH(4, A4)
+	UPDATEHASH(8, A5)
+	UPDATEHASH(12, A6)
+	UPDATEHASH(16, A7)
+	UPDATEHASH(20, S1)
+	UPDATEHASH(24, S2)
+	UPDATEHASH(28, S3)
+
+	ADD	$64, A1
+	BLTU	A1, A2, loop
+
+end:
+	RET
+
+// func hasZkn() bool
+TEXT ·hasZkn(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zkn
+	MOV	$1, T0
+#else
+	MOV	ZERO, T0
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
+
+// func hasZbb() bool
+TEXT ·hasZbb(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zbb
+	MOV	$1, T0
+#else
+#ifdef GORISCV_zkn
+	MOV	$1, T0 // Zkn includes the rotates and ANDN, as Zbkb
+#else
+	MOV	ZERO, T0
+#endif
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
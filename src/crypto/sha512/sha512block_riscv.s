@@ -0,0 +1,379 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// SHA512 block routines for riscv. See sha512block.go for the algorithm.
+//
+// blockZkn uses the SHA512SIG and SHA512SUM instructions from the Zknh
+// extension. blockZbb computes the same functions with the rotates of
+// Zbb. Both use ANDN for Ch, so neither has data-dependent branches or
+// table lookups.
+//
+// The working variables a..h live in A3, A4, A5, A6, A7, S1, S2 and S3,
+// and are renamed rather than moved between rounds. The 16-word message
+// schedule is kept on the stack at 8(SP), S5 points at the round
+// constants for the current 16 rounds, A1 at the current block and A2
+// at the end of p.
+
+#define W(index) (8+((index)&15)*8)(SP)
+
+// Wt = Mt; for 0 <= t <= 15, leaving Wt in T0.
+// p need not be aligned, so assemble the big-endian word a byte at a time.
+#define LOAD(index) \
+	MOVBU	((index)*8)(A1), T0; \
+	MOVBU	((index)*8+1)(A1), T1; \
+	MOVBU	((index)*8+2)(A1), T2; \
+	MOVBU	((index)*8+3)(A1), T3; \
+	SLL	$56, T0; \
+	SLL	$48, T1; \
+	SLL	$40, T2; \
+	SLL	$32, T3; \
+	OR	T1, T0; \
+	OR	T3, T2; \
+	MOVBU	((index)*8+4)(A1), T1; \
+	MOVBU	((index)*8+5)(A1), T3; \
+	OR	T2, T0; \
+	MOVBU	((index)*8+6)(A1), T2; \
+	MOVBU	((index)*8+7)(A1), T4; \
+	SLL	$24, T1; \
+	SLL	$16, T3; \
+	SLL	$8, T2; \
+	OR	T3, T1; \
+	OR	T4, T2; \
+	OR	T1, T0; \
+	OR	T2, T0; \
+	MOV	T0, W(index)
+
+// Wt = SIGMA1(Wt-2) + Wt-7 + SIGMA0(Wt-15) + Wt-16; for 16 <= t <= 79,
+// leaving Wt in T0.
+#define SCHEDULEZKN(index) \
+	MOV	W((index)+14), T0; \
+	MOV	W((index)+1), T1; \
+	MOV	W((index)+9), T2; \
+	MOV	W(index), T3; \
+	SHA512SIG1	T0, T0; \
+	SHA512SIG0	T1, T1; \
+	ADD	T2, T3; \
+	ADD	T1, T0; \
+	ADD	T3, T0; \
+	MOV	T0, W(index)
+
+// T1 = h + BIGSIGMA1(e) + Ch(e, f, g) + Kt + Wt
+// T2 = BIGSIGMA0(a) + Maj(a, b, c)
+// d += T1; h = T1 + T2
+// Wt is passed in T0.
+#define ROUNDZKN(index, a, b, c, d, e, f, g, h) \
+	MOV	((index)*8)(S5), T1; \
+	ADD	T0, h; \
+	SHA512SUM1	e, T2; \
+	ADD	T1, h; \
+	AND	e, f, T3; \
+	ANDN	e, g, T4; \
+	ADD	T2, h; \
+	OR	T4, T3; \
+	SHA512SUM0	a, T2; \
+	ADD	T3, h; \
+	OR	a, b, T1; \
+	AND	a, b, T4; \
+	AND	c, T1; \
+	ADD	h, d; \
+	OR	T4, T1; \
+	ADD	T2, h; \
+	ADD	T1, h
+
+//   SIGMA0(x) = ROTR(1,x) XOR ROTR(8,x) XOR SHR(7,x)
+//   SIGMA1(x) = ROTR(19,x) XOR ROTR(61,x) XOR SHR(6,x)
+#define SCHEDULEZBB(index) \
+	MOV	W((index)+14), T0; \
+	MOV	W((index)+1), T1; \
+	RORI	$19, T0, T2; \
+	RORI	$61, T0, T3; \
+	SRL	$6, T0, T0; \
+	XOR	T3, T2; \
+	XOR	T2, T0; \
+	RORI	$1, T1, T2; \
+	RORI	$8, T1, T3; \
+	SRL	$7, T1, T1; \
+	XOR	T3, T2; \
+	XOR	T2, T1; \
+	MOV	W((index)+9), T2; \
+	MOV	W(index), T3; \
+	ADD	T1, T0; \
+	ADD	T2, T3; \
+	ADD	T3, T0; \
+	MOV	T0, W(index)
+
+//   BIGSIGMA0(x) = ROTR(28,x) XOR ROTR(34,x) XOR ROTR(39,x)
+//   BIGSIGMA1(x) = ROTR(14,x) XOR ROTR(18,x) XOR ROTR(41,x)
+#define ROUNDZBB(index, a, b, c, d, e, f, g, h) \
+	MOV	((index)*8)(S5), T1; \
+	ADD	T0, h; \
+	RORI	$14, e, T2; \
+	RORI	$18, e, T3; \
+	ADD	T1, h; \
+	RORI	$41, e, T4; \
+	XOR	T3, T2; \
+	AND	e, f, T3; \
+	XOR	T4, T2; \
+	ANDN	e, g, T4; \
+	ADD	T2, h; \
+	OR	T4, T3; \
+	RORI	$28, a, T2; \
+	ADD	T3, h; \
+	RORI	$34, a, T3; \
+	RORI	$39, a, T4; \
+	XOR	T3, T2; \
+	OR	a, b, T1; \
+	XOR	T4, T2; \
+	AND	a, b, T4; \
+	AND	c, T1; \
+	ADD	h, d; \
+	OR	T4, T1; \
+	ADD	T2, h; \
+	ADD	T1, h
+
+// Hi += a..h for the digest at A0.
+#define UPDATEHASH(off, r) \
+	MOV	(off)(A0), T0; \
+	ADD	T0, r; \
+	MOV	r, (off)(A0)
+
+// func blockZkn(dig *digest, p []byte)
+TEXT ·blockZkn(SB),0,$128-32
+	MOV	dig+0(FP), A0
+	MOV	p_base+8(FP), A1
+	MOV	p_len+16(FP), A2
+	AND	$~127, A2
+	BEQ	A2, ZERO, end
+	ADD	A1, A2
+
+	MOV	0(A0), A3
+	MOV	8(A0), A4
+	MOV	16(A0), A5
+	MOV	24(A0), A6
+	MOV	32(A0), A7
+	MOV	40(A0), S1
+	MOV	48(A0), S2
+	MOV	56(A0), S3
+
+loop:
+	MOV	·_K(SB), S5
+
+	LOAD(0)
+	ROUNDZKN(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(1)
+	ROUNDZKN(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(2)
+	ROUNDZKN(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(3)
+	ROUNDZKN(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(4)
+	ROUNDZKN(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(5)
+	ROUNDZKN(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(6)
+	ROUNDZKN(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(7)
+	ROUNDZKN(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	LOAD(8)
+	ROUNDZKN(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(9)
+	ROUNDZKN(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(10)
+	ROUNDZKN(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(11)
+	ROUNDZKN(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(12)
+	ROUNDZKN(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(13)
+	ROUNDZKN(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(14)
+	ROUNDZKN(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(15)
+	ROUNDZKN(15, A4, A5, A6, A7, S1, S2, S3, A3)
+
+	MOV	$4, S6
+schedule:
+	ADD	$128, S5
+	SCHEDULEZKN(0)
+	ROUNDZKN(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZKN(1)
+	ROUNDZKN(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZKN(2)
+	ROUNDZKN(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZKN(3)
+	ROUNDZKN(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZKN(4)
+	ROUNDZKN(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZKN(5)
+	ROUNDZKN(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZKN(6)
+	ROUNDZKN(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZKN(7)
+	ROUNDZKN(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	SCHEDULEZKN(8)
+	ROUNDZKN(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZKN(9)
+	ROUNDZKN(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZKN(10)
+	ROUNDZKN(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZKN(11)
+	ROUNDZKN(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZKN(12)
+	ROUNDZKN(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZKN(13)
+	ROUNDZKN(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZKN(14)
+	ROUNDZKN(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZKN(15)
+	ROUNDZKN(15, A4, A5, A6, A7, S1, S2, S3, A3)
+	ADD	$-1, S6
+	BNE	S6, ZERO, schedule
+
+	UPDATEHASH(0, A3)
+	UPDATEHASH(8, A4)
+	UPDATEHASH(16, A5)
+	UPDATEHASH(24, A6)
+	UPDATEHASH(32, A7)
+	UPDATEHASH(40, S1)
+	UPDATEHASH(48, S2)
+	UPDATEHASH(56, S3)
+
+	ADD	$128, A1
+	BLTU	A1, A2, loop
+
+end:
+	RET
+
+// func blockZbb(dig *digest, p []byte)
+TEXT ·blockZbb(SB),0,$128-32
+	MOV	dig+0(FP), A0
+	MOV	p_base+8(FP), A1
+	MOV	p_len+16(FP), A2
+	AND	$~127, A2
+	BEQ	A2, ZERO, end
+	ADD	A1, A2
+
+	MOV	0(A0), A3
+	MOV	8(A0), A4
+	MOV	16(A0), A5
+	MOV	24(A0), A6
+	MOV	32(A0), A7
+	MOV	40(A0), S1
+	MOV	48(A0), S2
+	MOV	56(A0), S3
+
+loop:
+	MOV	·_K(SB), S5
+
+	LOAD(0)
+	ROUNDZBB(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(1)
+	ROUNDZBB(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(2)
+	ROUNDZBB(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(3)
+	ROUNDZBB(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(4)
+	ROUNDZBB(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(5)
+	ROUNDZBB(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(6)
+	ROUNDZBB(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(7)
+	ROUNDZBB(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	LOAD(8)
+	ROUNDZBB(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	LOAD(9)
+	ROUNDZBB(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	LOAD(10)
+	ROUNDZBB(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	LOAD(11)
+	ROUNDZBB(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	LOAD(12)
+	ROUNDZBB(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	LOAD(13)
+	ROUNDZBB(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	LOAD(14)
+	ROUNDZBB(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	LOAD(15)
+	ROUNDZBB(15, A4, A5, A6, A7, S1, S2, S3, A3)
+
+	MOV	$4, S6
+schedule:
+	ADD	$128, S5
+	SCHEDULEZBB(0)
+	ROUNDZBB(0, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZBB(1)
+	ROUNDZBB(1, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZBB(2)
+	ROUNDZBB(2, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZBB(3)
+	ROUNDZBB(3, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZBB(4)
+	ROUNDZBB(4, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZBB(5)
+	ROUNDZBB(5, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZBB(6)
+	ROUNDZBB(6, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZBB(7)
+	ROUNDZBB(7, A4, A5, A6, A7, S1, S2, S3, A3)
+	SCHEDULEZBB(8)
+	ROUNDZBB(8, A3, A4, A5, A6, A7, S1, S2, S3)
+	SCHEDULEZBB(9)
+	ROUNDZBB(9, S3, A3, A4, A5, A6, A7, S1, S2)
+	SCHEDULEZBB(10)
+	ROUNDZBB(10, S2, S3, A3, A4, A5, A6, A7, S1)
+	SCHEDULEZBB(11)
+	ROUNDZBB(11, S1, S2, S3, A3, A4, A5, A6, A7)
+	SCHEDULEZBB(12)
+	ROUNDZBB(12, A7, S1, S2, S3, A3, A4, A5, A6)
+	SCHEDULEZBB(13)
+	ROUNDZBB(13, A6, A7, S1, S2, S3, A3, A4, A5)
+	SCHEDULEZBB(14)
+	ROUNDZBB(14, A5, A6, A7, S1, S2, S3, A3, A4)
+	SCHEDULEZBB(15)
+	ROUNDZBB(15, A4, A5, A6, A7, S1, S2, S3, A3)
+	ADD	$-1, S6
+	BNE	S6, ZERO, schedule
+
+	UPDATEHASH(0, A3)
+	UPDATEHASH(8, A4)
+	UPDATEHASH(16, A5)
+	UPDATEHASH(24, A6)
+	UPDATEHASH(32, A7)
+	UPDATEHASH(40, S1)
+	UPDATEHASH(48, S2)
+	UPDATEHASH(56, S3)
+
+	ADD	$128, A1
+	BLTU	A1, A2, loop
+
+end:
+	RET
+
+// func hasZkn() bool
+TEXT ·hasZkn(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zkn
+	MOV	$1, T0
+#else
+	MOV	ZERO, T0
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
+
+// func hasZbb() bool
+TEXT ·hasZbb(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zbb
+	MOV	$1, T0
+#else
+#ifdef GORISCV_zkn
+	MOV	$1, T0 // Zkn includes the rotates and ANDN, as Zbkb
+#else
+	MOV	ZERO, T0
+#endif
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
@@ -35,6 +35,7 @@ import (
 var pkgDeps = map[string][]string{
 	// L0 is the lowest level, core, nearly unavoidable packages.
 	"errors":                  {},
+	"internal/cpu":            {},
 	"io":                      {"errors", "sync"},
 	"runtime":                 {"unsafe", "runtime/internal/atomic", "runtime/internal/sys"},
 	"runtime/internal/sys":    {},
@@ -46,6 +47,7 @@ var pkgDeps = map[string][]string{
 
 	"L0": {
 		"errors",
+		"internal/cpu",
 		"io",
 		"runtime",
 		"runtime/internal/atomic",
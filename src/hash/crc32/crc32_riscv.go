@@ -0,0 +1,89 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// RISC-V-specific hardware-assisted CRC32 algorithms. See crc32.go for a
+// description of the interface that each architecture-specific file
+// implements.
+
+package crc32
+
+import "internal/cpu"
+
+const (
+	clmulMinLen    = 64
+	clmulAlignMask = 15 // fold 16 bytes at a time
+)
+
+// hasZbc reports whether the code was built for a CPU with the Zbc
+// carry-less multiplication extension. It is defined in crc32_riscv.s.
+func hasZbc() bool
+
+var useCLMUL = hasZbc() || cpu.RISCV.HasZbc
+
+// ieeeCLMUL and castagnoliCLMUL are defined in crc32_riscv.s and use the
+// CLMUL, CLMULH and CLMULR instructions. len(p) must be a non-zero
+// multiple of 16.
+//go:noescape
+func ieeeCLMUL(crc uint32, p []byte) uint32
+
+//go:noescape
+func castagnoliCLMUL(crc uint32, p []byte) uint32
+
+func archAvailableCastagnoli() bool {
+	return useCLMUL
+}
+
+var archCastagnoliTable8 *slicing8Table
+
+func archInitCastagnoli() {
+	if !useCLMUL {
+		panic("not available")
+	}
+	// We still use slicing-by-8 for small buffers.
+	archCastagnoliTable8 = slicingMakeTable(Castagnoli)
+}
+
+func archUpdateCastagnoli(crc uint32, p []byte) uint32 {
+	if !useCLMUL {
+		panic("not available")
+	}
+	if len(p) >= clmulMinLen {
+		aligned := len(p) &^ clmulAlignMask
+		crc = castagnoliCLMUL(crc, p[:aligned])
+		p = p[aligned:]
+	}
+	if len(p) == 0 {
+		return crc
+	}
+	return slicingUpdate(crc, archCastagnoliTable8, p)
+}
+
+func archAvailableIEEE() bool {
+	return useCLMUL
+}
+
+var archIeeeTable8 *slicing8Table
+
+func archInitIEEE() {
+	if !useCLMUL {
+		panic("not available")
+	}
+	// We still use slicing-by-8 for small buffers.
+	archIeeeTable8 = slicingMakeTable(IEEE)
+}
+
+func archUpdateIEEE(crc uint32, p []byte) uint32 {
+	if !useCLMUL {
+		panic("not available")
+	}
+	if len(p) >= clmulMinLen {
+		aligned := len(p) &^ clmulAlignMask
+		crc = ieeeCLMUL(crc, p[:aligned])
+		p = p[aligned:]
+	}
+	if len(p) == 0 {
+		return crc
+	}
+	return slicingUpdate(crc, archIeeeTable8, p)
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// func hasZbc() bool
+TEXT ·hasZbc(SB),NOSPLIT,$0-1
+#ifdef GORISCV_zbc
+	MOV	$1, T0
+#else
+	MOV	ZERO, T0
+#endif
+	MOVB	T0, ret+0(FP)
+	RET
+
+// The CRC is computed in the bit-reflected domain, where a doubleword
+// loaded little-endian holds the polynomial coefficients from high to
+// low degree. Multiplying two such doublewords with CLMUL and CLMULH
+// gives their reflected product times x, which the folding constants
+// absorb by using x^(n-1) in place of x^n.
+//
+// The 128-bit remainder {T0, T1} is folded forward over each 16 bytes:
+//
+//	{T0, T1} = T0*(x^192 mod P) + T1*(x^128 mod P) + next 16 bytes
+//
+// and the final 16 bytes are reduced to 32 bits a doubleword at a time
+// with a Barrett reduction.
+//
+// Register usage:
+//
+//	A0:	CRC value
+//	A1:	Input buffer pointer
+//	A2:	End of the input buffer
+//	A4:	x^191 mod P
+//	A5:	x^127 mod P
+//	A6:	floor(x^96 / P), without its leading term
+//	A7:	P << 32
+
+// Reduce the doubleword s, whose low 32 bits already include the CRC,
+// leaving the CRC in A0.
+#define BARRETT(s) \
+	CLMUL	A6, s, T2; \
+	SLL	$1, T2; \
+	XOR	s, T2; \
+	CLMULR	A7, T2, T2; \
+	SRL	$32, T2, A0
+
+// func ieeeCLMUL(crc uint32, p []byte) uint32
+TEXT ·ieeeCLMUL(SB),NOSPLIT,$0-36
+	MOV	$0x65673b4600000000, A4
+	MOV	$0x9ba54c6f00000000, A5
+	MOV	$0x5a72d812fb808b20, A6
+	MOV	$0xedb8832000000000, A7
+	JMP	clmulBody<>(SB)
+
+// func castagnoliCLMUL(crc uint32, p []byte) uint32
+TEXT ·castagnoliCLMUL(SB),NOSPLIT,$0-36
+	MOV	$0x3743f7bd00000000, A4
+	MOV	$0x3171d43000000000, A5
+	MOV	$0xa434f61c6f5389f8, A6
+	MOV	$0x82f63b7800000000, A7
+	JMP	clmulBody<>(SB)
+
+TEXT clmulBody<>(SB),NOSPLIT,$0
+	MOVWU	crc+0(FP), A0
+	MOV	p_base+8(FP), A1
+	MOV	p_len+16(FP), A2
+	XOR	$-1, A0
+	SLL	$32, A0
+	SRL	$32, A0
+	ADD	A1, A2
+
+	MOV	0(A1), T0
+	MOV	8(A1), T1
+	XOR	A0, T0
+	ADD	$16, A1
+	BEQ	A1, A2, reduce
+
+fold:
+	CLMUL	A4, T0, T2
+	CLMULH	A4, T0, T3
+	CLMUL	A5, T1, T4
+	CLMULH	A5, T1, T5
+	MOV	0(A1), T0
+	MOV	8(A1), T1
+	ADD	$16, A1
+	XOR	T2, T0
+	XOR	T3, T1
+	XOR	T4, T0
+	XOR	T5, T1
+	BNE	A1, A2, fold
+
+reduce:
+	BARRETT(T0)
+	XOR	A0, T1
+	BARRETT(T1)
+	XOR	$-1, A0
+	MOVW	A0, ret+32(FP)
+	RET
@@ -0,0 +1,79 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cpu
+
+// The AT_HWCAP bits for single-letter extensions, from asm/hwcap.h.
+const (
+	hwcap_C = 1 << ('C' - 'A')
+	hwcap_V = 1 << ('V' - 'A')
+)
+
+// The RISCV_HWPROBE_KEY_IMA_EXT_0 bits, from asm/hwprobe.h.
+const (
+	hwprobe_IMA_C      = 1 << 1
+	hwprobe_IMA_V      = 1 << 2
+	hwprobe_EXT_ZBA    = 1 << 3
+	hwprobe_EXT_ZBB    = 1 << 4
+	hwprobe_EXT_ZBS    = 1 << 5
+	hwprobe_EXT_ZICBOZ = 1 << 6
+	hwprobe_EXT_ZBC    = 1 << 7
+	hwprobe_EXT_ZBKB   = 1 << 8
+	hwprobe_EXT_ZBKC   = 1 << 9
+	hwprobe_EXT_ZBKX   = 1 << 10
+	hwprobe_EXT_ZKND   = 1 << 11
+	hwprobe_EXT_ZKNE   = 1 << 12
+	hwprobe_EXT_ZKNH   = 1 << 13
+	hwprobe_EXT_ZKSED  = 1 << 14
+	hwprobe_EXT_ZKSH   = 1 << 15
+)
+
+// RISCV reports the extensions beyond RV64G implemented by every CPU
+// the program may run on. A feature is reported only if the kernel
+// says it is present; code built for it with GORISCV may use it
+// regardless.
+var RISCV riscv
+
+type riscv struct {
+	HasC      bool // compressed instructions
+	HasV      bool // vector
+	HasZba    bool // address generation
+	HasZbb    bool // basic bit manipulation
+	HasZbs    bool // single-bit instructions
+	HasZbc    bool // carry-less multiplication
+	HasZbkb   bool // bit manipulation for cryptography
+	HasZbkc   bool // carry-less multiplication for cryptography
+	HasZbkx   bool // crossbar permutations
+	HasZicboz bool // cache-block zero
+	HasZknd   bool // AES decryption
+	HasZkne   bool // AES encryption
+	HasZknh   bool // SHA-2 hash functions
+	HasZksed  bool // SM4 block cipher
+	HasZksh   bool // SM3 hash function
+}
+
+// runtime_riscvFeatures is implemented in the runtime. It returns
+// AT_HWCAP and the RISCV_HWPROBE_KEY_IMA_EXT_0 value of the riscv_hwprobe
+// system call, or zero for whichever the kernel does not provide.
+func runtime_riscvFeatures() (hwcap, ext0 uint64)
+
+func init() {
+	hwcap, ext0 := runtime_riscvFeatures()
+
+	RISCV.HasC = hwcap&hwcap_C != 0 || ext0&hwprobe_IMA_C != 0
+	RISCV.HasV = hwcap&hwcap_V != 0 || ext0&hwprobe_IMA_V != 0
+	RISCV.HasZba = ext0&hwprobe_EXT_ZBA != 0
+	RISCV.HasZbb = ext0&hwprobe_EXT_ZBB != 0
+	RISCV.HasZbs = ext0&hwprobe_EXT_ZBS != 0
+	RISCV.HasZbc = ext0&hwprobe_EXT_ZBC != 0
+	RISCV.HasZbkb = ext0&hwprobe_EXT_ZBKB != 0
+	RISCV.HasZbkc = ext0&hwprobe_EXT_ZBKC != 0
+	RISCV.HasZbkx = ext0&hwprobe_EXT_ZBKX != 0
+	RISCV.HasZicboz = ext0&hwprobe_EXT_ZICBOZ != 0
+	RISCV.HasZknd = ext0&hwprobe_EXT_ZKND != 0
+	RISCV.HasZkne = ext0&hwprobe_EXT_ZKNE != 0
+	RISCV.HasZknh = ext0&hwprobe_EXT_ZKNH != 0
+	RISCV.HasZksed = ext0&hwprobe_EXT_ZKSED != 0
+	RISCV.HasZksh = ext0&hwprobe_EXT_ZKSH != 0
+}
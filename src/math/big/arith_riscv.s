@@ -0,0 +1,193 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !math_big_pure_go
+
+#include "textflag.h"
+
+// This file provides fast assembly versions for the elementary
+// arithmetic operations on vectors implemented in arith.go.
+
+// RISC-V has no carry flag, so carries are recovered with SLTU: after
+// s = a + b, the addition carried out exactly when s < a. Adding a
+// carry-in as well can carry at most once between the two additions,
+// so the two SLTU results are combined with OR.
+//
+// The loops are scheduled for simple in-order cores: loads are issued
+// well ahead of their uses, and MUL and MULHU are issued before the
+// carry arithmetic that consumes them.
+
+// func mulWW(x, y Word) (z1, z0 Word)
+TEXT ·mulWW(SB),NOSPLIT,$0
+	MOV	x+0(FP), A1
+	MOV	y+8(FP), A2
+	MULHU	A1, A2, A3
+	MUL	A1, A2, A4
+	MOV	A3, z1+16(FP)
+	MOV	A4, z0+24(FP)
+	RET
+
+// func divWW(x1, x0, y Word) (q, r Word)
+TEXT ·divWW(SB),NOSPLIT,$0
+	JMP	·divWW_g(SB) // RISC-V has no multiword division
+
+// func addVV(z, x, y []Word) (c Word)
+TEXT ·addVV(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A3
+	MOV	ZERO, A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	MOV	0(A3), T1
+	ADD	$8, A2
+	ADD	$8, A3
+	ADD	T0, T1, T2	// x + y
+	SLTU	T0, T2, T3	// carry from x + y
+	ADD	A4, T2, T4	// x + y + c
+	SLTU	A4, T4, T5	// carry from adding c
+	MOV	T4, 0(A1)
+	OR	T3, T5, A4
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+72(FP)
+	RET
+
+// func subVV(z, x, y []Word) (c Word)
+TEXT ·subVV(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A3
+	MOV	ZERO, A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	MOV	0(A3), T1
+	ADD	$8, A2
+	ADD	$8, A3
+	SUB	T1, T0, T2	// x - y
+	SLTU	T1, T0, T3	// borrow from x - y
+	SUB	A4, T2, T4	// x - y - c
+	SLTU	A4, T2, T5	// borrow from subtracting c
+	MOV	T4, 0(A1)
+	OR	T3, T5, A4
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+72(FP)
+	RET
+
+// func addVW(z, x []Word, y Word) (c Word)
+TEXT ·addVW(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	ADD	$8, A2
+	ADD	A4, T0, T1
+	SLTU	A4, T1, A4
+	MOV	T1, 0(A1)
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+56(FP)
+	RET
+
+// func subVW(z, x []Word, y Word) (c Word)
+TEXT ·subVW(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	ADD	$8, A2
+	SUB	A4, T0, T1
+	SLTU	A4, T0, A4
+	MOV	T1, 0(A1)
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+56(FP)
+	RET
+
+// func shlVU(z, x []Word, s uint) (c Word)
+TEXT ·shlVU(SB),NOSPLIT,$0
+	JMP	·shlVU_g(SB)
+
+// func shrVU(z, x []Word, s uint) (c Word)
+TEXT ·shrVU(SB),NOSPLIT,$0
+	JMP	·shrVU_g(SB)
+
+// func mulAddVWW(z, x []Word, y, r Word) (c Word)
+TEXT ·mulAddVWW(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A3
+	MOV	r+56(FP), A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	ADD	$8, A2
+	MUL	T0, A3, T1	// lo(x * y)
+	MULHU	T0, A3, T2	// hi(x * y)
+	ADD	A4, T1, T1
+	SLTU	A4, T1, T3
+	MOV	T1, 0(A1)
+	ADD	T3, T2, A4
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+64(FP)
+	RET
+
+// func addMulVVW(z, x []Word, y Word) (c Word)
+TEXT ·addMulVVW(SB),NOSPLIT,$0
+	MOV	z+0(FP), A1
+	MOV	z_len+8(FP), A0
+	MOV	x+24(FP), A2
+	MOV	y+48(FP), A3
+	MOV	ZERO, A4 // c
+	BEQ	A0, ZERO, done
+loop:
+	MOV	0(A2), T0
+	MOV	0(A1), T4
+	ADD	$8, A2
+	MUL	T0, A3, T1	// lo(x * y)
+	MULHU	T0, A3, T2	// hi(x * y)
+	ADD	A4, T1, T1
+	SLTU	A4, T1, T3
+	ADD	T4, T1, T1
+	SLTU	T4, T1, T5
+	MOV	T1, 0(A1)
+	ADD	T3, T2, T2
+	ADD	T5, T2, A4
+	ADD	$8, A1
+	ADD	$-1, A0
+	BNE	A0, ZERO, loop
+done:
+	MOV	A4, c+56(FP)
+	RET
+
+// func divWVW(z []Word, xn Word, x []Word, y Word) (r Word)
+TEXT ·divWVW(SB),NOSPLIT,$0
+	JMP	·divWVW_g(SB)
+
+// func bitLen(x Word) (n int)
+TEXT ·bitLen(SB),NOSPLIT,$0
+	JMP	·bitLen_g(SB) // no count-leading-zeros instruction in the base ISA
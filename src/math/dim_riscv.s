@@ -0,0 +1,80 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+#define PosInf 0x7FF0000000000000
+#define NaN    0x7FF8000000000001
+#define NegInf 0xFFF0000000000000
+
+// FCLASS.D result bits. ClassNaN covers both signaling and quiet NaNs.
+#define ClassNegInf (1<<0)
+#define ClassPosInf (1<<7)
+#define ClassNaN    (3<<8)
+
+// func Dim(x, y float64) float64
+TEXT ·Dim(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOVD	y+8(FP), FT1
+	// Inf-Inf and NaN inputs give NaN, which is the answer.
+	FSUBD	FT1, FT0, FT0
+	FEQD	FT0, FT0, T0
+	BEQ	T0, ZERO, done
+	FMVDX	ZERO, FT1
+	FMAXD	FT1, FT0, FT0
+done:
+	MOVD	FT0, ret+16(FP)
+	RET
+
+// func Max(x, y float64) float64
+TEXT ·Max(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOVD	y+8(FP), FT1
+	FCLASSD	FT0, T0
+	FCLASSD	FT1, T1
+	OR	T1, T0
+	// +Inf wins over NaN.
+	ANDI	$ClassPosInf, T0, T1
+	BNE	T1, ZERO, isPosInf
+	// FMAX.D returns the other operand when one is NaN.
+	ANDI	$ClassNaN, T0, T1
+	BNE	T1, ZERO, isNaN
+	// FMAX.D orders -0 below +0, as Max requires.
+	FMAXD	FT1, FT0, FT0
+	MOVD	FT0, ret+16(FP)
+	RET
+isPosInf:
+	MOV	$PosInf, T0
+	MOV	T0, ret+16(FP)
+	RET
+isNaN:
+	MOV	$NaN, T0
+	MOV	T0, ret+16(FP)
+	RET
+
+// func Min(x, y float64) float64
+TEXT ·Min(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOVD	y+8(FP), FT1
+	FCLASSD	FT0, T0
+	FCLASSD	FT1, T1
+	OR	T1, T0
+	// -Inf wins over NaN.
+	ANDI	$ClassNegInf, T0, T1
+	BNE	T1, ZERO, isNegInf
+	// FMIN.D returns the other operand when one is NaN.
+	ANDI	$ClassNaN, T0, T1
+	BNE	T1, ZERO, isNaN
+	// FMIN.D orders -0 below +0, as Min requires.
+	FMIND	FT1, FT0, FT0
+	MOVD	FT0, ret+16(FP)
+	RET
+isNegInf:
+	MOV	$NegInf, T0
+	MOV	T0, ret+16(FP)
+	RET
+isNaN:
+	MOV	$NaN, T0
+	MOV	T0, ret+16(FP)
+	RET
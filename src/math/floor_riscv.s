@@ -0,0 +1,58 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// Every float64 with magnitude of at least 2**52 is an integer, as are
+// ±Inf and NaN; those are returned unchanged. Everything smaller fits in
+// an int64, so round trip it through an integer register using the
+// rounding mode we want, then restore the sign of x so that, for
+// example, Ceil(-0.5) is -0.
+
+#define Two52 0x4330000000000000
+
+// func Floor(x float64) float64
+TEXT ·Floor(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOV	$Two52, T0
+	FMVDX	T0, FT1
+	FSGNJXD	FT0, FT0, FT2
+	FLTD	FT1, FT2, T0	// false for NaN
+	BEQ	T0, ZERO, done
+	FCVTLD.RDN	FT0, T0
+	FCVTDL	T0, FT2
+	FSGNJD	FT0, FT2, FT0
+done:
+	MOVD	FT0, ret+8(FP)
+	RET
+
+// func Ceil(x float64) float64
+TEXT ·Ceil(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOV	$Two52, T0
+	FMVDX	T0, FT1
+	FSGNJXD	FT0, FT0, FT2
+	FLTD	FT1, FT2, T0	// false for NaN
+	BEQ	T0, ZERO, done
+	FCVTLD.RUP	FT0, T0
+	FCVTDL	T0, FT2
+	FSGNJD	FT0, FT2, FT0
+done:
+	MOVD	FT0, ret+8(FP)
+	RET
+
+// func Trunc(x float64) float64
+TEXT ·Trunc(SB),NOSPLIT,$0
+	MOVD	x+0(FP), FT0
+	MOV	$Two52, T0
+	FMVDX	T0, FT1
+	FSGNJXD	FT0, FT0, FT2
+	FLTD	FT1, FT2, T0	// false for NaN
+	BEQ	T0, ZERO, done
+	FCVTLD.RTZ	FT0, T0
+	FCVTDL	T0, FT2
+	FSGNJD	FT0, FT2, FT0
+done:
+	MOVD	FT0, ret+8(FP)
+	RET
@@ -18,15 +18,6 @@ TEXT ·Atan2(SB),NOSPLIT,$0
 TEXT ·Atan(SB),NOSPLIT,$0
 	JMP ·atan(SB)
 
-TEXT ·Dim(SB),NOSPLIT,$0
-	JMP ·dim(SB)
-
-TEXT ·Min(SB),NOSPLIT,$0
-	JMP ·min(SB)
-
-TEXT ·Max(SB),NOSPLIT,$0
-	JMP ·max(SB)
-
 TEXT ·Exp2(SB),NOSPLIT,$0
 	JMP ·exp2(SB)
 
@@ -36,15 +27,6 @@ TEXT ·Expm1(SB),NOSPLIT,$0
 TEXT ·Exp(SB),NOSPLIT,$0
 	JMP ·exp(SB)
 
-TEXT ·Floor(SB),NOSPLIT,$0
-	JMP ·floor(SB)
-
-TEXT ·Ceil(SB),NOSPLIT,$0
-	JMP ·ceil(SB)
-
-TEXT ·Trunc(SB),NOSPLIT,$0
-	JMP ·trunc(SB)
-
 TEXT ·Frexp(SB),NOSPLIT,$0
 	JMP ·frexp(SB)
 
@@ -84,8 +66,5 @@ TEXT ·Sin(SB),NOSPLIT,$0
 TEXT ·Cos(SB),NOSPLIT,$0
 	JMP ·cos(SB)
 
-TEXT ·Sqrt(SB),NOSPLIT,$0
-	JMP ·sqrt(SB)
-
 TEXT ·Tan(SB),NOSPLIT,$0
 	JMP ·tan(SB)
@@ -0,0 +1,13 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "syscall"
+
+const supportsCloseOnExec = false
+
+func hostname() (name string, err error) {
+	return "", NewSyscallError("hostname", syscall.ENOSYS)
+}
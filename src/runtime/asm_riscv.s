@@ -185,26 +185,12 @@ noxor:
 // equivalent Go code.
 
 // func eqstring(s1, s2 string) bool
-TEXT runtime·eqstring(SB),NOSPLIT,$0-33
-	MOV	s1_base+0(FP), T0
-	MOV	s2_base+16(FP), T1
-	MOV	$1, T2
-	MOVB	T2, ret+32(FP)
-	BNE	T0, T1, diff_len
-	RET
-diff_len:
-	MOV	s1_len+8(FP), T2
-	ADD	T0, T2, T3
-loop:
-	BNE	T0, T3, 2(PC)
-	RET
-	MOVBU	(T0), T5
-	ADD	$1, T0
-	MOVBU	(T1), T6
-	ADD	$1, T1
-	BEQ	T5, T6, loop
-	MOVB	ZERO, ret+32(FP)
-	RET
+TEXT runtime·eqstring(SB),NOSPLIT,$-8-33
+	MOV	s1_base+0(FP), A1
+	MOV	s1_len+8(FP), A3
+	MOV	s2_base+16(FP), A2
+	MOV	$ret+32(FP), A5
+	JMP	runtime·memeqbody<>(SB)
 
 /*
  * support for morestack
@@ -275,26 +261,136 @@ TEXT runtime·return0(SB), NOSPLIT, $0
 TEXT runtime·memequal(SB),NOSPLIT,$-8-25
 	MOV	a+0(FP), A1
 	MOV	b+8(FP), A2
-	BEQ	A1, A2, eq
 	MOV	size+16(FP), A3
-	ADD	A1, A3, A4
-loop:
-	BNE	A1, A4, test
-	MOV	$1, A1
-	MOVB	A1, ret+24(FP)
-	RET
-test:
-	MOVBU	(A1), A6
+	MOV	$ret+24(FP), A5
+	JMP	runtime·memeqbody<>(SB)
+
+// On entry:
+// A1 points to the start of a
+// A2 points to the start of b
+// A3 is the length of both
+// A5 points to the result (1 if equal, 0 if not)
+//
+// On exit:
+// T0-T3, A1-A4, A6 and A7 are clobbered
+//
+// Unaligned loads may trap and be emulated, so only aligned words are
+// loaded. When a and b are misaligned relative to each other, the
+// words of b are shifted and merged as in memmove.
+TEXT runtime·memeqbody<>(SB),NOSPLIT,$-8-0
+	BEQ	A1, A2, eq
+
+	SLTU	$16, A3, T0
+	BNE	T0, ZERO, bytes
+
+	XOR	A1, A2, T0
+	AND	$7, T0
+	BNE	T0, ZERO, mis
+
+	// a and b have the same alignment.
+	// Do one byte at a time until both are eight-aligned.
+align:
+	AND	$7, A1, T0
+	BEQ	T0, ZERO, aligned
+	MOVBU	(A1), T1
+	MOVBU	(A2), T2
+	BNE	T1, T2, noteq
 	ADD	$1, A1
-	MOVBU	(A2), A7
 	ADD	$1, A2
-	BEQ	A6, A7, loop
+	ADD	$-1, A3
+	JMP	align
+
+aligned:
+	// Do 32 bytes at a time as long as there is room.
+	SLTU	$32, A3, T0
+	BNE	T0, ZERO, wordscheck
+loop32:
+	MOV	0(A1), T0
+	MOV	0(A2), T1
+	BNE	T0, T1, noteq
+	MOV	8(A1), T2
+	MOV	8(A2), T3
+	BNE	T2, T3, noteq
+	MOV	16(A1), T0
+	MOV	16(A2), T1
+	BNE	T0, T1, noteq
+	MOV	24(A1), T2
+	MOV	24(A2), T3
+	BNE	T2, T3, noteq
+	ADD	$32, A1
+	ADD	$32, A2
+	ADD	$-32, A3
+	SLTU	$32, A3, T0
+	BEQ	T0, ZERO, loop32
+
+	// Do eight bytes at a time as long as there is room.
+wordscheck:
+	SLTU	$8, A3, T0
+	BNE	T0, ZERO, bytes
+words:
+	MOV	(A1), T0
+	MOV	(A2), T1
+	BNE	T0, T1, noteq
+	ADD	$8, A1
+	ADD	$8, A2
+	ADD	$-8, A3
+	SLTU	$8, A3, T0
+	BEQ	T0, ZERO, words
+	JMP	bytes
+
+	// a and b are misaligned relative to each other.
+	// Do one byte at a time until a is eight-aligned.
+mis:
+	AND	$7, A1, T0
+	BEQ	T0, ZERO, misaligned
+	MOVBU	(A1), T1
+	MOVBU	(A2), T2
+	BNE	T1, T2, noteq
+	ADD	$1, A1
+	ADD	$1, A2
+	ADD	$-1, A3
+	JMP	mis
+
+misaligned:
+	// At least nine bytes are left. b is k bytes past the aligned
+	// address in A7; A4 = 8*k and A6 = -8*k.
+	AND	$7, A2, T0
+	SLL	$3, T0, A4
+	SUB	A4, ZERO, A6
+	SUB	T0, A2, A7
+	MOV	(A7), T1
+mwords:
+	MOV	8(A7), T2
+	SRL	A4, T1, T1
+	SLL	A6, T2, T3
+	OR	T3, T1
+	MOV	(A1), T0
+	BNE	T0, T1, noteq
+	MOV	T2, T1
+	ADD	$8, A1
+	ADD	$8, A2
+	ADD	$8, A7
+	ADD	$-8, A3
+	SLTU	$8, A3, T0
+	BEQ	T0, ZERO, mwords
+
+	// Finish off the remaining bytes.
+bytes:
+	BEQ	A3, ZERO, eq
+	MOVBU	(A1), T1
+	MOVBU	(A2), T2
+	BNE	T1, T2, noteq
+	ADD	$1, A1
+	ADD	$1, A2
+	ADD	$-1, A3
+	JMP	bytes
 
-	MOVB	ZERO, ret+24(FP)
-	RET
 eq:
-	MOV	$1, A1
-	MOVB	A1, ret+24(FP)
+	MOV	$1, T0
+	MOVB	T0, (A5)
+	RET
+noteq:
+	MOVB	ZERO, (A5)
 	RET
 
 // func memequal_varlen(a, b unsafe.Pointer) bool
@@ -363,7 +459,15 @@ TEXT runtime·jmpdefer(SB), NOSPLIT, $-8-16
 	JALR	ZERO, T0
 
 // func procyield(cycles uint32)
+// PAUSE is a FENCE hint, so cores without Zihintpause run it as a no-op.
 TEXT runtime·procyield(SB),NOSPLIT,$0-0
+	MOVWU	cycles+0(FP), T0
+	BEQ	T0, ZERO, done
+again:
+	PAUSE
+	ADD	$-1, T0
+	BNE	T0, ZERO, again
+done:
 	RET
 
 // Switch to m->g0's stack, call fn(g).
@@ -431,6 +535,178 @@ TEXT runtime·memhash_varlen(SB),NOSPLIT,$40-24
 	MOV	A1, ret+16(FP)
 	RET
 
+// memhash computes the same hash as memhash in hash64.go, but it
+// reads misaligned data with aligned loads, which are much cheaper
+// than misaligned ones on most RISC-V hardware.
+
+// LOAD8 loads the eight bytes at q into T2. It clobbers q and T0.
+#define LOAD8(q) \
+	AND	$7, q, T0 \
+	BNE	T0, ZERO, 3(PC) \
+	MOV	(q), T2 \
+	JMP	9(PC) \
+	SUB	T0, q \
+	SLL	$3, T0 \
+	MOV	(q), T2 \
+	MOV	8(q), q \
+	SRL	T0, T2 \
+	SUB	T0, ZERO, T0 \
+	SLL	T0, q \
+	OR	q, T2
+
+// LOAD4 loads the four bytes at q into T2, zero-extended. It clobbers
+// q, T0 and T1. The second load is only done if the bytes straddle
+// two words, so that it cannot fault.
+#define LOAD4(q) \
+	AND	$7, q, T0 \
+	SUB	T0, q \
+	SLL	$3, T0 \
+	MOV	(q), T2 \
+	SRL	T0, T2 \
+	MOV	$32, T1 \
+	BGEU	T1, T0, 5(PC) \
+	MOV	8(q), T1 \
+	SUB	T0, ZERO, T0 \
+	SLL	T0, T1 \
+	OR	T1, T2 \
+	SLL	$32, T2 \
+	SRL	$32, T2
+
+// MIX sets h to rotl_31(h*ma) * mb. It clobbers T0.
+#define MIX(h, ma, mb) \
+	MUL	ma, h \
+	SLL	$31, h, T0 \
+	SRL	$33, h \
+	OR	T0, h \
+	MUL	mb, h
+
+// func memhash(p unsafe.Pointer, seed, s uintptr) uintptr
+TEXT runtime·memhash(SB),NOSPLIT,$-8-32
+	MOV	p+0(FP), A0
+	MOV	seed+8(FP), A1
+	MOV	s+16(FP), A2
+	MOV	$0xea38ec9079f01541, A7	// m1
+	MOV	$0x2723a30d96da1399, T3	// m2
+	MOV	$0x83cf8eadf876d2d7, T4	// m3
+	MOV	$0xdbcfc27b643df693, T5	// m4
+	MOV	$runtime·hashkey(SB), T0
+	MOV	(T0), A3
+	MUL	A2, A3
+	ADD	A1, A3		// h = seed + s*hashkey[0]
+	SLTU	$33, A2, T0
+	BNE	T0, ZERO, tail
+
+	// More than 32 bytes: hash 32 bytes at a time into v1-v4 in
+	// A3-A6, and the rest as above.
+	MOV	$runtime·hashkey(SB), T0
+	MOV	8(T0), A4
+	MUL	A1, A4
+	MOV	16(T0), A5
+	MUL	A1, A5
+	MOV	24(T0), A6
+	MUL	A1, A6
+loop:
+	MOV	A0, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	ADD	$8, A0, A1
+	LOAD8(A1)
+	XOR	T2, A4
+	MIX(A4, T3, T4)
+	ADD	$16, A0, A1
+	LOAD8(A1)
+	XOR	T2, A5
+	MIX(A5, T4, T5)
+	ADD	$24, A0, A1
+	LOAD8(A1)
+	XOR	T2, A6
+	MIX(A6, T5, A7)
+	ADD	$32, A0
+	ADD	$-32, A2
+	SLTU	$32, A2, T0
+	BEQ	T0, ZERO, loop
+	XOR	A4, A3
+	XOR	A5, A3
+	XOR	A6, A3
+
+tail:
+	BEQ	A2, ZERO, done
+	SLTU	$4, A2, T0
+	BNE	T0, ZERO, small
+	SLTU	$9, A2, T0
+	BNE	T0, ZERO, upto8
+	SLTU	$17, A2, T0
+	BNE	T0, ZERO, upto16
+
+	// 17 to 32 bytes.
+	MOV	A0, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	ADD	$8, A0, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	ADD	A2, A0, A1
+	ADD	$-16, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	ADD	A2, A0, A1
+	ADD	$-8, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	JMP	done
+
+upto16:
+	MOV	A0, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	ADD	A2, A0, A1
+	ADD	$-8, A1
+	LOAD8(A1)
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	JMP	done
+
+upto8:
+	MOV	A0, A1
+	LOAD4(A1)
+	XOR	T2, A3
+	ADD	A2, A0, A1
+	ADD	$-4, A1
+	LOAD4(A1)
+	SLL	$32, T2
+	XOR	T2, A3
+	MIX(A3, A7, T3)
+	JMP	done
+
+small:
+	MOVBU	(A0), T1
+	XOR	T1, A3
+	SRL	$1, A2, T1
+	ADD	A0, T1
+	MOVBU	(T1), T1
+	SLL	$8, T1
+	XOR	T1, A3
+	ADD	A2, A0, T1
+	MOVBU	-1(T1), T1
+	SLL	$16, T1
+	XOR	T1, A3
+	MIX(A3, A7, T3)
+
+done:
+	SRL	$29, A3, T0
+	XOR	T0, A3
+	MUL	T4, A3
+	SRL	$32, A3, T0
+	XOR	T0, A3
+	MOV	A3, ret+24(FP)
+	RET
+
 // func asminit()
 TEXT runtime·asminit(SB),NOSPLIT,$-8-0
 	RET
@@ -559,6 +835,8 @@ CALLFN(·call1073741824, 1073741824)
 // func goexit(neverCallThisFunction)
 // The top-most function running on a goroutine
 // returns to goexit+PCQuantum.
+// The NOPs are compressed exactly when PCQuantum is 2, so that
+// goexit+PCQuantum is always an instruction boundary.
 TEXT runtime·goexit(SB),NOSPLIT,$-8-0
 	MOV	A0, A0	// NOP
 	MOV	A0, A0	// NOP
@@ -581,83 +859,247 @@ setbar:
 	RET
 
 // func IndexByte(s []byte, c byte) int
-TEXT bytes·IndexByte(SB),NOSPLIT,$0-40
+TEXT bytes·IndexByte(SB),NOSPLIT,$-8-40
 	MOV	s+0(FP), A1
 	MOV	s_len+8(FP), A2
 	MOVBU	c+24(FP), A3	// byte to find
-	MOV	A1, A4		// store base for later
-	ADD	A1, A2		// end
-	ADD	$-1, A1
-
-loop:
-	ADD	$1, A1
-	BEQ	A1, A2, notfound
-	MOVBU	(A1), A5
-	BNE	A3, A5, loop
-
-	SUB	A4, A1		// remove base
-	MOV	A1, ret+32(FP)
-	RET
-
-notfound:
-	MOV	$-1, A1
-	MOV	A1, ret+32(FP)
-	RET
+	MOV	$ret+32(FP), A5
+	JMP	runtime·indexbytebody<>(SB)
 
 // func IndexByte(s string, c byte) int
-TEXT strings·IndexByte(SB),NOSPLIT,$0-32
+TEXT strings·IndexByte(SB),NOSPLIT,$-8-32
 	MOV	p+0(FP), A1
 	MOV	b_len+8(FP), A2
 	MOVBU	c+16(FP), A3	// byte to find
+	MOV	$ret+24(FP), A5
+	JMP	runtime·indexbytebody<>(SB)
+
+// On entry:
+// A1 points to the start of s
+// A2 is the length of s
+// A3 is the byte to find
+// A5 points to the result (the index of the byte, or -1)
+//
+// On exit:
+// T0-T5, A1, A2, A4 and A6 are clobbered
+TEXT runtime·indexbytebody<>(SB),NOSPLIT,$-8-0
 	MOV	A1, A4		// store base for later
 	ADD	A1, A2		// end
-	ADD	$-1, A1
 
-loop:
+	// Do one byte at a time until eight-aligned.
+align:
+	BEQ	A1, A2, notfound
+	AND	$7, A1, T0
+	BEQ	T0, ZERO, aligned
+	MOVBU	(A1), T1
+	BEQ	A3, T1, found
 	ADD	$1, A1
+	JMP	align
+
+aligned:
+	// Look at eight bytes at a time as long as there is room.
+	// XORing a word with c in every byte turns each copy of c into
+	// a zero byte, and (x - 0x01..01) & ^x & 0x80..80 is nonzero iff
+	// x has a zero byte.
+	ADD	$-7, A2, A6
+	MOV	$0x0101010101010101, T2
+	MUL	A3, T2, T3	// c in every byte
+	SLL	$7, T2, T4	// 0x80 in every byte
+words:
+	BGEU	A1, A6, bytes
+	MOV	(A1), T0
+	XOR	T3, T0
+	SUB	T2, T0, T1
+	XOR	$-1, T0, T5
+	AND	T5, T1
+	AND	T4, T1
+	BNE	T1, ZERO, bytes	// c is in this word; find it a byte at a time
+	ADD	$8, A1
+	JMP	words
+
+	// Finish off the remaining bytes.
+bytes:
 	BEQ	A1, A2, notfound
-	MOVBU	(A1), A5
-	BNE	A3, A5, loop
+	MOVBU	(A1), T1
+	BEQ	A3, T1, found
+	ADD	$1, A1
+	JMP	bytes
 
+found:
 	SUB	A4, A1		// remove base
-	MOV	A1, ret+24(FP)
+	MOV	A1, (A5)
 	RET
 
 notfound:
-	MOV	$-1, A1
-	MOV	A1, ret+24(FP)
+	MOV	$-1, T0
+	MOV	T0, (A5)
 	RET
 
-// TODO: share code with memequal?
 // func Equal(a, b []byte) bool
-TEXT bytes·Equal(SB),NOSPLIT,$0-49
+TEXT bytes·Equal(SB),NOSPLIT,$-8-49
 	MOV	a_len+8(FP), A3
 	MOV	b_len+32(FP), A4
 	BNE	A3, A4, noteq		// unequal lengths are not equal
-
 	MOV	a+0(FP), A1
 	MOV	b+24(FP), A2
-	ADD	A1, A3		// end
-
-loop:
-	BEQ	A1, A3, equal		// reached the end
-	MOVBU	(A1), A6
-	ADD	$1, A1
-	MOVBU	(A2), A7
-	ADD	$1, A2
-	BEQ	A6, A7, loop
+	MOV	$ret+48(FP), A5
+	JMP	runtime·memeqbody<>(SB)
 
 noteq:
 	MOVB	ZERO, ret+48(FP)
 	RET
 
-equal:
-	MOV	$1, A1
-	MOVB	A1, ret+48(FP)
+// func cmpstring(s1, s2 string) int
+TEXT runtime·cmpstring(SB),NOSPLIT,$-8-40
+	MOV	s1_base+0(FP), A1
+	MOV	s1_len+8(FP), A2
+	MOV	s2_base+16(FP), A3
+	MOV	s2_len+24(FP), A4
+	MOV	$ret+32(FP), A5
+	JMP	runtime·cmpbody<>(SB)
+
+// func Compare(a, b []byte) int
+TEXT bytes·Compare(SB),NOSPLIT,$-8-56
+	MOV	a+0(FP), A1
+	MOV	a_len+8(FP), A2
+	MOV	b+24(FP), A3
+	MOV	b_len+32(FP), A4
+	MOV	$ret+48(FP), A5
+	JMP	runtime·cmpbody<>(SB)
+
+// On entry:
+// A1 points to the start of s1
+// A2 is the length of s1
+// A3 points to the start of s2
+// A4 is the length of s2
+// A5 points to the result (-1/0/1 will be written here)
+//
+// On exit:
+// T0-T5, A1, A3, A6 and A7 are clobbered
+//
+// When a pair of words differs, the byte loop at the end finds the
+// first differing byte among them.
+TEXT runtime·cmpbody<>(SB),NOSPLIT,$-8-0
+	BEQ	A1, A3, samebytes	// same starting pointers; compare lengths
+
+	// A6 is the number of bytes to compare, min(A2, A4).
+	MOV	A2, A6
+	BLTU	A2, A4, 2(PC)
+	MOV	A4, A6
+
+	SLTU	$16, A6, T0
+	BNE	T0, ZERO, bytes
+
+	XOR	A1, A3, T0
+	AND	$7, T0
+	BNE	T0, ZERO, mis
+
+	// s1 and s2 have the same alignment.
+	// Do one byte at a time until both are eight-aligned.
+align:
+	AND	$7, A1, T0
+	BEQ	T0, ZERO, words
+	MOVBU	(A1), T1
+	MOVBU	(A3), T2
+	BNE	T1, T2, bytediff
+	ADD	$1, A1
+	ADD	$1, A3
+	ADD	$-1, A6
+	JMP	align
+
+	// Do eight bytes at a time as long as there is room.
+words:
+	MOV	(A1), T1
+	MOV	(A3), T2
+	BNE	T1, T2, bytes
+	ADD	$8, A1
+	ADD	$8, A3
+	ADD	$-8, A6
+	SLTU	$8, A6, T0
+	BEQ	T0, ZERO, words
+	JMP	bytes
+
+	// s1 and s2 are misaligned relative to each other.
+	// Do one byte at a time until s1 is eight-aligned.
+mis:
+	AND	$7, A1, T0
+	BEQ	T0, ZERO, misaligned
+	MOVBU	(A1), T1
+	MOVBU	(A3), T2
+	BNE	T1, T2, bytediff
+	ADD	$1, A1
+	ADD	$1, A3
+	ADD	$-1, A6
+	JMP	mis
+
+misaligned:
+	// At least nine bytes are left. s2 is k bytes past the aligned
+	// address in T4; A7 = 8*k and T3 = -8*k.
+	AND	$7, A3, T0
+	SLL	$3, T0, A7
+	SUB	A7, ZERO, T3
+	SUB	T0, A3, T4
+	MOV	(T4), T5
+mwords:
+	MOV	8(T4), T2
+	SRL	A7, T5, T5
+	SLL	T3, T2, T0
+	OR	T0, T5
+	MOV	(A1), T1
+	BNE	T1, T5, bytes
+	MOV	T2, T5
+	ADD	$8, A1
+	ADD	$8, A3
+	ADD	$8, T4
+	ADD	$-8, A6
+	SLTU	$8, A6, T0
+	BEQ	T0, ZERO, mwords
+
+	// Compare the remaining bytes.
+bytes:
+	BEQ	A6, ZERO, samebytes	// all compared bytes were the same; compare lengths
+	MOVBU	(A1), T1
+	MOVBU	(A3), T2
+	BNE	T1, T2, bytediff
+	ADD	$1, A1
+	ADD	$1, A3
+	ADD	$-1, A6
+	JMP	bytes
+
+bytediff:
+	BLTU	T1, T2, less
+	JMP	greater
+
+samebytes:
+	BLTU	A2, A4, less
+	BLTU	A4, A2, greater
+	MOV	ZERO, (A5)
+	RET
+less:
+	MOV	$-1, T0
+	MOV	T0, (A5)
+	RET
+greater:
+	MOV	$1, T0
+	MOV	T0, (A5)
 	RET
 
 TEXT runtime·stackBarrier(SB),NOSPLIT,$0
-	WORD $0
+	// We came here via a RET to an overwritten LR.
+	// A0 may be live (see return0). Other registers are available.
+
+	// Get the original return PC, g.stkbar[g.stkbarPos].savedLRVal.
+	MOV	(g_stkbar+slice_array)(g), T0
+	MOV	g_stkbarPos(g), T1
+	MOV	$stkbar__size, T2
+	MUL	T1, T2
+	ADD	T0, T2
+	MOV	stkbar_savedLRVal(T2), T2
+	// Record that this stack barrier was hit.
+	ADD	$1, T1
+	MOV	T1, g_stkbarPos(g)
+	// Jump to the original return PC.
+	JMP	(T2)
 
 // func cgocallback_gofunc(fv uintptr, frame uintptr, framesize, ctxt uintptr)
 TEXT ·cgocallback_gofunc(SB),NOSPLIT,$24-32
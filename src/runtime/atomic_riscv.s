@@ -4,8 +4,6 @@
 
 #include "textflag.h"
 
-#define FENCE WORD $0x0ff0000f
-
 // func publicationBarrier()
 TEXT ·publicationBarrier(SB),NOSPLIT,$-8-0
 	FENCE
@@ -0,0 +1,202 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime_test
+
+import (
+	"bytes"
+	"fmt"
+	"internal/testenv"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The bare-metal tests build testdata/testprognone for none/riscv and
+// boot it with go_none_riscv_exec, from misc/riscv, on QEMU: once in
+// machine mode and once in supervisor mode under SBI firmware.
+
+var bareMetalModes = []struct {
+	name    string
+	ldflags string
+}{
+	{"machine", ""},
+	{"supervisor", "-E _rt0_riscv_none_sbi -T 0x80200000"},
+}
+
+var testprognone struct {
+	sync.Mutex
+	dir    string
+	target map[string]buildexe
+}
+
+func buildBareMetal(t *testing.T, ldflags string) (string, error) {
+	testprognone.Lock()
+	defer testprognone.Unlock()
+	if testprognone.dir == "" {
+		dir, err := ioutil.TempDir("", "go-build")
+		if err != nil {
+			t.Fatalf("failed to create temp directory: %v", err)
+		}
+		testprognone.dir = dir
+		toRemove = append(toRemove, dir)
+		testprognone.target = make(map[string]buildexe)
+	}
+	if target, ok := testprognone.target[ldflags]; ok {
+		return target.exe, target.err
+	}
+
+	var target buildexe
+	exe := filepath.Join(testprognone.dir, fmt.Sprintf("testprognone%d", len(testprognone.target)))
+	cmd := exec.Command(testenv.GoToolPath(t), "build", "-ldflags="+ldflags, "-o", exe)
+	cmd.Dir = "testdata/testprognone"
+	cmd.Env = crossEnv("none", "riscv")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		target.err = fmt.Errorf("building testprognone -ldflags=%q: %v\n%s", ldflags, err, out)
+	} else {
+		target.exe = exe
+	}
+	testprognone.target[ldflags] = target
+	return target.exe, target.err
+}
+
+// prepareBareMetal skips the test unless QEMU can be used, and builds
+// testprognone with ldflags.
+func prepareBareMetal(t *testing.T, ldflags string) string {
+	testenv.MustHaveGoBuild(t)
+	if testing.Short() {
+		t.Skip("skipping bare-metal boot in short mode")
+	}
+	for _, prog := range []string{"go_none_riscv_exec", "qemu-system-riscv64"} {
+		if _, err := exec.LookPath(prog); err != nil {
+			t.Skipf("skipping: %s not found", prog)
+		}
+	}
+	exe, err := buildBareMetal(t, ldflags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exe
+}
+
+func runBareMetal(t *testing.T, ldflags string, args ...string) (string, error) {
+	exe := prepareBareMetal(t, ldflags)
+	return bootBareMetal(t, exec.Command("go_none_riscv_exec", append([]string{exe}, args...)...))
+}
+
+func bootBareMetal(t *testing.T, cmd *exec.Cmd) (string, error) {
+	var b bytes.Buffer
+	cmd.Stdout = &b
+	cmd.Stderr = &b
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	timer := time.AfterFunc(5*time.Minute, func() { cmd.Process.Kill() })
+	err := cmd.Wait()
+	timer.Stop()
+	// QEMU's serial console may turn \n into \r\n.
+	return stripFirmwareBanner(strings.Replace(b.String(), "\r\n", "\n", -1)), err
+}
+
+// firmwareField matches the "Name    : value" lines of OpenSBI's banner.
+var firmwareField = regexp.MustCompile(`^\S.*\S\s+: `)
+
+// stripFirmwareBanner removes the banner that OpenSBI prints on the
+// console before it enters the program in supervisor mode: a logo and
+// then a block of firmware fields.
+func stripFirmwareBanner(out string) string {
+	i := strings.Index(out, "OpenSBI v")
+	if i < 0 || strings.TrimSpace(out[:i]) != "" {
+		return out
+	}
+	n := 0
+	fields := false
+	for _, line := range strings.SplitAfter(out, "\n") {
+		if firmwareField.MatchString(line) {
+			fields = true
+		} else if fields {
+			break
+		}
+		n += len(line)
+	}
+	return out[n:]
+}
+
+// crossEnv returns the test environment with GOOS and GOARCH replaced.
+func crossEnv(goos, goarch string) []string {
+	var env []string
+	for _, e := range testEnv(exec.Command("")).Env {
+		if !strings.HasPrefix(e, "GOOS=") && !strings.HasPrefix(e, "GOARCH=") && !strings.HasPrefix(e, "CGO_ENABLED=") {
+			env = append(env, e)
+		}
+	}
+	return append(env, "GOOS="+goos, "GOARCH="+goarch, "CGO_ENABLED=0")
+}
+
+func TestBareMetal(t *testing.T) {
+	for _, mode := range bareMetalModes {
+		for _, name := range []string{"Goroutines", "Preempt", "Sleep", "Fault"} {
+			out, err := runBareMetal(t, mode.ldflags, name)
+			if err != nil || out != "OK\n" {
+				t.Errorf("%s mode, %s: %v\n%s", mode.name, name, err, out)
+			}
+		}
+	}
+}
+
+func TestBareMetalArgs(t *testing.T) {
+	for _, mode := range bareMetalModes {
+		out, err := runBareMetal(t, mode.ldflags, "Args", "a", "bc", "-d=e")
+		if want := "a,bc,-d=e\n"; err != nil || out != want {
+			t.Errorf("%s mode: got %q, %v; want %q", mode.name, out, err, want)
+		}
+	}
+}
+
+func TestBareMetalExit(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		status int
+		out    string
+	}{
+		{"Exit", 7, ""},
+		{"Panic", 2, "panic: bare metal\n"},
+	} {
+		for _, mode := range bareMetalModes {
+			out, err := runBareMetal(t, mode.ldflags, tt.name)
+			if !strings.HasPrefix(out, tt.out) {
+				t.Errorf("%s mode, %s: output does not start with %q:\n%s", mode.name, tt.name, tt.out, out)
+			}
+			if want := fmt.Sprintf("exit status %d", tt.status); err == nil || err.Error() != want {
+				t.Errorf("%s mode, %s: got %v, want %s", mode.name, tt.name, err, want)
+			}
+		}
+	}
+}
+
+// TestBareMetalWrongMode starts each entry point in the other mode and
+// checks that the runtime stops and says why. QEMU without firmware
+// starts the machine at the start of RAM, so the supervisor-mode entry
+// point is linked there for that.
+func TestBareMetalWrongMode(t *testing.T) {
+	for _, tt := range []struct {
+		ldflags string
+		bios    string
+		want    string
+	}{
+		{"-T 0x80200000", "default", "runtime: started in supervisor mode"},
+		{"-E _rt0_riscv_none_sbi", "none", "runtime: started in machine mode"},
+	} {
+		exe := prepareBareMetal(t, tt.ldflags)
+		out, err := bootBareMetal(t, exec.Command("qemu-system-riscv64", "-machine", "virt", "-bios", tt.bios, "-m", "128M", "-nographic", "-kernel", exe))
+		if err == nil || !strings.HasPrefix(out, tt.want) {
+			t.Errorf("-ldflags=%q with -bios %s: got %v, want failure starting with %q:\n%s", tt.ldflags, tt.bios, err, tt.want, out)
+		}
+	}
+}
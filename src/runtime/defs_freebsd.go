@@ -10,6 +10,7 @@ Input to cgo.
 GOARCH=amd64 go tool cgo -cdefs defs_freebsd.go >defs_freebsd_amd64.h
 GOARCH=386 go tool cgo -cdefs defs_freebsd.go >defs_freebsd_386.h
 GOARCH=arm go tool cgo -cdefs defs_freebsd.go >defs_freebsd_arm.h
+GOARCH=riscv go tool cgo -cdefs defs_freebsd.go >defs_freebsd_riscv.h
 */
 
 package runtime
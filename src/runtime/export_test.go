@@ -30,6 +30,9 @@ var Xadduintptr = atomic.Xadduintptr
 
 var FuncPC = funcPC
 
+var Memmove = memmove
+var MemclrNoHeapPointers = memclrNoHeapPointers
+
 var Fastlog2 = fastlog2
 
 var Atoi = atoi
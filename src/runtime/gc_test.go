@@ -5,7 +5,9 @@
 package runtime_test
 
 import (
+	"internal/testenv"
 	"os"
+	"os/exec"
 	"reflect"
 	"runtime"
 	"runtime/debug"
@@ -109,6 +111,30 @@ func TestGcRescan(t *testing.T) {
 	}
 }
 
+func TestGcStackBarriers(t *testing.T) {
+	if runtime.GOARCH == "ppc64" || runtime.GOARCH == "ppc64le" {
+		t.Skip("gcstackbarrierall doesn't work on ppc64")
+	}
+	testenv.MustHaveGoBuild(t)
+
+	exe, err := buildTestProg(t, "testprog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Install a stack barrier at every frame and collect constantly,
+	// so that deep stacks return through stackBarrier many times.
+	cmd := testEnv(exec.Command(exe, "GCStackBarriers"))
+	cmd.Env = append(cmd.Env, "GODEBUG=gcrescanstacks=1,gcstackbarrierall=1", "GOGC=1")
+	got, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s: %v\n%s", exe, err, got)
+	}
+	want := "OK\n"
+	if string(got) != want {
+		t.Fatalf("expected %q, but got %q", want, string(got))
+	}
+}
+
 func TestGcLastTime(t *testing.T) {
 	ms := new(runtime.MemStats)
 	t0 := time.Now().UnixNano()
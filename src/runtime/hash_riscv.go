@@ -0,0 +1,11 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// memhash is in asm_riscv.s. It computes the same hash as the
+// memhash in hash64.go.
+func memhash(p unsafe.Pointer, seed, s uintptr) uintptr
@@ -18,7 +18,6 @@
 #define SC_ 3
 #define OR_ 8
 #define AND_ 12
-#define FENCE WORD $0x0ff0000f
 
 TEXT ·Cas(SB), NOSPLIT, $0-17
 	MOV	ptr+0(FP), A0
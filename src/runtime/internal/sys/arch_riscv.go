@@ -5,15 +5,14 @@
 package sys
 
 const (
-	ArchFamily    = RISCV
-	BigEndian     = 0
-	CacheLineSize = 64   // TODO(prattmic)
-	PhysPageSize  = 4096 // TODO(prattmic)
-	// TODO(sorear) On non-RVC hardware jumping to goexit+2 is likely to crash.  Is that a problem?
-	PCQuantum    = 2
-	Int64Align   = 8
-	HugePageSize = 1 << 21
-	MinFrameSize = 8
+	ArchFamily          = RISCV
+	BigEndian           = 0
+	CacheLineSize       = GoriscvCacheLineSize // 64 with GORISCV's _zic64b, else 128
+	DefaultPhysPageSize = 4096                 // the runtime uses AT_PAGESZ from auxv on Linux
+	PCQuantum           = 4 - 2*GoriscvRVC     // GORISCV=G binaries have no 2-byte instructions
+	Int64Align          = 8
+	HugePageSize        = 1 << 21
+	MinFrameSize        = 8
 )
 
 type Uintreg uint64
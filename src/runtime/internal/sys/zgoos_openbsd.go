@@ -11,6 +11,7 @@ const GoosFreebsd = 0
 const GoosLinux = 0
 const GoosNacl = 0
 const GoosNetbsd = 0
+const GoosNone = 0
 const GoosOpenbsd = 1
 const GoosPlan9 = 0
 const GoosSolaris = 0
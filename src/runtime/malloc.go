@@ -245,6 +245,11 @@ func mallocinit() {
 	// See https://golang.org/issue/5049
 	// TODO(rsc): Fix after 1.1.
 	limit = 0
+	if GOOS == "none" {
+		// Without virtual memory the arena has to fit in the
+		// RAM that is actually there.
+		limit = memlimit()
+	}
 
 	// Set up the allocation arena, a contiguous area of memory where
 	// allocated data will be found. The arena begins with a bitmap large
@@ -447,7 +452,9 @@ func (h *mheap) sysAlloc(n uintptr) unsafe.Pointer {
 	}
 
 	// If using 64-bit, our reservation is all we have.
-	if h.arena_end-h.arena_start > _MaxArena32 {
+	// So it is on bare metal, where the arena was sized to
+	// fit the RAM and the spans array covers no more.
+	if h.arena_end-h.arena_start > _MaxArena32 || GOOS == "none" {
 		return nil
 	}
 
@@ -0,0 +1,69 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// Without virtual memory there is nothing to reserve or map: the
+// heap arena is carved out of the RAM between the end of the image
+// and sysPoolStart, and memory the runtime allocates off the heap
+// comes from the pool between sysPoolStart and the end of RAM.
+
+const sysPoolStart = ramBase + ramSize - ramSize/16
+
+// sysPoolNext is the next free byte in the pool. Pool memory is only
+// returned when it is the most recent allocation.
+var sysPoolNext uintptr = sysPoolStart
+
+// Don't split the stack as this function may be invoked without a valid G,
+// which prevents us from allocating more stack.
+//go:nosplit
+func sysAlloc(n uintptr, sysStat *uint64) unsafe.Pointer {
+	n = round(n, physPageSize)
+	if n > ramBase+ramSize-sysPoolNext {
+		return nil
+	}
+	v := unsafe.Pointer(sysPoolNext)
+	sysPoolNext += n
+	memclrNoHeapPointers(v, n)
+	mSysStatInc(sysStat, n)
+	return v
+}
+
+func sysUnused(v unsafe.Pointer, n uintptr) {
+}
+
+func sysUsed(v unsafe.Pointer, n uintptr) {
+}
+
+// Don't split the stack as this function may be invoked without a valid G,
+// which prevents us from allocating more stack.
+//go:nosplit
+func sysFree(v unsafe.Pointer, n uintptr, sysStat *uint64) {
+	mSysStatDec(sysStat, n)
+	n = round(n, physPageSize)
+	if uintptr(v)+n == sysPoolNext {
+		sysPoolNext = uintptr(v)
+	}
+}
+
+func sysFault(v unsafe.Pointer, n uintptr) {
+}
+
+func sysReserve(v unsafe.Pointer, n uintptr, reserved *bool) unsafe.Pointer {
+	p := uintptr(v)
+	if p < firstmoduledata.end || p > sysPoolStart || n > sysPoolStart-p {
+		return nil
+	}
+	*reserved = true
+	return v
+}
+
+func sysMap(v unsafe.Pointer, n uintptr, reserved bool, sysStat *uint64) {
+	mSysStatInc(sysStat, n)
+	// Nothing clears RAM on a real board, but the heap expects
+	// fresh memory to be zero.
+	memclrNoHeapPointers(v, n)
+}
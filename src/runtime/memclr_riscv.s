@@ -6,41 +6,179 @@
 
 #include "textflag.h"
 
+// Every eight-aligned word in the buffer is cleared with a single
+// store, so that the garbage collector never sees half of a pointer.
+// Fewer than 16 bytes are cleared through a jump table on n when ptr is
+// eight-aligned, and otherwise, if they hold no whole aligned word, by
+// jumping into an unrolled run of byte stores. The code the jumps land
+// in is laid out with NORVC, so that every instruction in it is four
+// bytes long.
+
 // void runtime·memclrNoHeapPointers(void*, uintptr)
 TEXT runtime·memclrNoHeapPointers(SB),NOSPLIT,$0-16
 	MOV	ptr+0(FP), T1
 	MOV	n+8(FP), T2
-	ADD	T1, T2, T4
 
-	// If less than eight bytes, do one byte at a time.
-	SLTU	$8, T2, T3
-	BNE	T3, ZERO, outcheck
+	SLTU	$16, T2, T3
+	BNE	T3, ZERO, small
 
 	// Do one byte at a time until eight-aligned.
-	JMP	aligncheck
 align:
+	AND	$7, T1, T3
+	BEQ	T3, ZERO, aligned
 	MOVB	ZERO, (T1)
 	ADD	$1, T1
-aligncheck:
-	AND	$7, T1, T3
-	BNE	T3, ZERO, align
+	ADD	$-1, T2
+	JMP	align
+
+aligned:
+	// Do 64 bytes at a time as long as there is room.
+	SLTU	$64, T2, T3
+	BNE	T3, ZERO, wordscheck
+loop64:
+	MOV	ZERO, 0(T1)
+	MOV	ZERO, 8(T1)
+	MOV	ZERO, 16(T1)
+	MOV	ZERO, 24(T1)
+	MOV	ZERO, 32(T1)
+	MOV	ZERO, 40(T1)
+	MOV	ZERO, 48(T1)
+	MOV	ZERO, 56(T1)
+	ADD	$64, T1
+	ADD	$-64, T2
+	SLTU	$64, T2, T3
+	BEQ	T3, ZERO, loop64
 
 	// Do eight bytes at a time as long as there is room.
-	ADD	$-7, T4, T5
-	JMP	wordscheck
+wordscheck:
+	SLTU	$8, T2, T3
+	BNE	T3, ZERO, table
 words:
 	MOV	ZERO, (T1)
 	ADD	$8, T1
-wordscheck:
-	SLTU	T5, T1, T3
-	BNE	T3, ZERO, words
+	ADD	$-8, T2
+	SLTU	$8, T2, T3
+	BEQ	T3, ZERO, words
+	JMP	table
 
-	JMP	outcheck
-out:
-	MOVB	ZERO, (T1)
-	ADD	$1, T1
-outcheck:
-	BNE	T1, T4, out
+	// Fewer than 16 bytes are left.
+small:
+	AND	$7, T1, T3
+	BEQ	T3, ZERO, table
+	ADD	T2, T3
+	SLTU	$16, T3, T3
+	BEQ	T3, ZERO, align
 
+	// Clear the last n of 15 unrolled bytes.
+	// Each byte takes four bytes of code.
+	ADD	T2, T1
+	NORVC
+	AUIPC	$0, T3
+	SLL	$2, T2, T4
+	SUB	T4, T3
+	JALR	ZERO, (16+15*4)(T3)
+	MOVB	ZERO, -15(T1)
+	MOVB	ZERO, -14(T1)
+	MOVB	ZERO, -13(T1)
+	MOVB	ZERO, -12(T1)
+	MOVB	ZERO, -11(T1)
+	MOVB	ZERO, -10(T1)
+	MOVB	ZERO, -9(T1)
+	MOVB	ZERO, -8(T1)
+	MOVB	ZERO, -7(T1)
+	MOVB	ZERO, -6(T1)
+	MOVB	ZERO, -5(T1)
+	MOVB	ZERO, -4(T1)
+	MOVB	ZERO, -3(T1)
+	MOVB	ZERO, -2(T1)
+	MOVB	ZERO, -1(T1)
+	RVC
 done:
 	RET
+
+	// Fewer than 16 bytes are left and T1 is eight-aligned.
+table:
+	NORVC
+	AUIPC	$0, T3
+	SLL	$2, T2, T4
+	ADD	T4, T3
+	JALR	ZERO, 16(T3)
+	JMP	done
+	JMP	clr1
+	JMP	clr2
+	JMP	clr3
+	JMP	clr4
+	JMP	clr5
+	JMP	clr6
+	JMP	clr7
+	JMP	clr8
+	JMP	clr9
+	JMP	clr10
+	JMP	clr11
+	JMP	clr12
+	JMP	clr13
+	JMP	clr14
+	JMP	clr15
+	RVC
+clr1:
+	MOVB	ZERO, 0(T1)
+	RET
+clr2:
+	MOVH	ZERO, 0(T1)
+	RET
+clr3:
+	MOVH	ZERO, 0(T1)
+	MOVB	ZERO, 2(T1)
+	RET
+clr4:
+	MOVW	ZERO, 0(T1)
+	RET
+clr5:
+	MOVW	ZERO, 0(T1)
+	MOVB	ZERO, 4(T1)
+	RET
+clr6:
+	MOVW	ZERO, 0(T1)
+	MOVH	ZERO, 4(T1)
+	RET
+clr7:
+	MOVW	ZERO, 0(T1)
+	MOVH	ZERO, 4(T1)
+	MOVB	ZERO, 6(T1)
+	RET
+clr8:
+	MOV	ZERO, 0(T1)
+	RET
+clr9:
+	MOV	ZERO, 0(T1)
+	MOVB	ZERO, 8(T1)
+	RET
+clr10:
+	MOV	ZERO, 0(T1)
+	MOVH	ZERO, 8(T1)
+	RET
+clr11:
+	MOV	ZERO, 0(T1)
+	MOVH	ZERO, 8(T1)
+	MOVB	ZERO, 10(T1)
+	RET
+clr12:
+	MOV	ZERO, 0(T1)
+	MOVW	ZERO, 8(T1)
+	RET
+clr13:
+	MOV	ZERO, 0(T1)
+	MOVW	ZERO, 8(T1)
+	MOVB	ZERO, 12(T1)
+	RET
+clr14:
+	MOV	ZERO, 0(T1)
+	MOVW	ZERO, 8(T1)
+	MOVH	ZERO, 12(T1)
+	RET
+clr15:
+	MOV	ZERO, 0(T1)
+	MOVW	ZERO, 8(T1)
+	MOVH	ZERO, 12(T1)
+	MOVB	ZERO, 14(T1)
+	RET
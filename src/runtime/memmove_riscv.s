@@ -6,93 +6,528 @@
 
 #include "textflag.h"
 
+// Register usage:
+//	A0 = to, A1 = from, A2 = n (bytes still to copy).
+// Going backward, A0 and A1 point just past the bytes still to copy.
+// Unaligned loads and stores may trap and be emulated, so every load
+// and store here is naturally aligned; when to and from are misaligned
+// relative to each other, aligned source words are shifted and merged.
+//
+// When to and from are both eight-aligned, every eight-aligned word is
+// copied with a single load and store, so that the garbage collector
+// never sees half of a pointer. Copies of fewer than 16 bytes are
+// dispatched on n: through a jump table when both pointers are
+// eight-aligned, and otherwise into an unrolled run of byte copies.
+// The code the jump tables land in is laid out with NORVC, so that
+// every instruction in it is four bytes long.
+
 // void runtime·memmove(void*, void*, uintptr)
 TEXT runtime·memmove(SB),NOSPLIT,$-0-24
-	MOV	to+0(FP), T0
-	MOV	from+8(FP), T1
-	MOV	n+16(FP), T2
-	ADD	T1, T2, T5
+	MOV	to+0(FP), A0
+	MOV	from+8(FP), A1
+	MOV	n+16(FP), A2
+	BEQ	A0, A1, done
 
 	// If the destination is ahead of the source, start at the end of the
 	// buffer and go backward.
-	BLTU	T1, T0, b
+	BLTU	A1, A0, b
+
+	SLTU	$16, A2, T0
+	BNE	T0, ZERO, f_small
 
-	// If less than eight bytes, do one byte at a time.
-	SLTU	$8, T2, T3
-	BNE	T3, ZERO, f_outcheck
+	XOR	A0, A1, T0
+	AND	$7, T0
+	BNE	T0, ZERO, f_mis
 
-	// Do one byte at a time until from is eight-aligned.
-	JMP	f_aligncheck
+	// to and from have the same alignment.
+	// Do one byte at a time until both are eight-aligned.
 f_align:
-	MOVB	(T1), T3
-	MOVB	T3, (T0)
-	ADD	$1, T0
-	ADD	$1, T1
-f_aligncheck:
-	AND	$7, T1, T3
-	BNE	T3, ZERO, f_align
+	AND	$7, A0, T0
+	BEQ	T0, ZERO, f_aligned
+	MOVBU	(A1), T1
+	MOVB	T1, (A0)
+	ADD	$1, A0
+	ADD	$1, A1
+	ADD	$-1, A2
+	JMP	f_align
+
+f_aligned:
+	// Do 64 bytes at a time as long as there is room.
+	SLTU	$64, A2, T0
+	BNE	T0, ZERO, f_wordscheck
+f_loop64:
+	MOV	0(A1), T0
+	MOV	8(A1), T1
+	MOV	16(A1), T2
+	MOV	24(A1), T3
+	MOV	32(A1), T4
+	MOV	40(A1), T5
+	MOV	48(A1), A3
+	MOV	56(A1), A4
+	MOV	T0, 0(A0)
+	MOV	T1, 8(A0)
+	MOV	T2, 16(A0)
+	MOV	T3, 24(A0)
+	MOV	T4, 32(A0)
+	MOV	T5, 40(A0)
+	MOV	A3, 48(A0)
+	MOV	A4, 56(A0)
+	ADD	$64, A0
+	ADD	$64, A1
+	ADD	$-64, A2
+	SLTU	$64, A2, T0
+	BEQ	T0, ZERO, f_loop64
 
 	// Do eight bytes at a time as long as there is room.
-	ADD	$-7, T5, T6
-	JMP	f_wordscheck
-f_words:
-	MOV	(T1), T3
-	MOV	T3, (T0)
-	ADD	$8, T0
-	ADD	$8, T1
 f_wordscheck:
-	SLTU	T6, T1, T3
-	BNE	T3, ZERO, f_words
+	SLTU	$8, A2, T0
+	BNE	T0, ZERO, small
+f_words:
+	MOV	(A1), T0
+	MOV	T0, (A0)
+	ADD	$8, A0
+	ADD	$8, A1
+	ADD	$-8, A2
+	SLTU	$8, A2, T0
+	BEQ	T0, ZERO, f_words
+	JMP	small
+
+	// to and from are misaligned relative to each other.
+	// Do one byte at a time until to is eight-aligned.
+f_mis:
+	AND	$7, A0, T0
+	BEQ	T0, ZERO, f_misaligned
+	MOVBU	(A1), T1
+	MOVB	T1, (A0)
+	ADD	$1, A0
+	ADD	$1, A1
+	ADD	$-1, A2
+	JMP	f_mis
+
+f_misaligned:
+	// At least nine bytes are left and from is k = from&7 bytes
+	// past an eight-aligned address, which goes in A7.
+	// Each destination word is the top 8-k bytes of one aligned
+	// source word and the bottom k bytes of the next one, so
+	// A5 = 8*k and A6 = -8*k, which SLL treats as 64-8*k.
+	// Whole aligned words are read, but never one that holds no
+	// byte of the source.
+	AND	$7, A1, T0
+	SLL	$3, T0, A5
+	SUB	A5, ZERO, A6
+	SUB	T0, A1, A7
+	MOV	(A7), T1
+
+	// Do 32 bytes at a time as long as there is room.
+	SLTU	$32, A2, T0
+	BNE	T0, ZERO, f_mwordscheck
+f_mloop32:
+	MOV	8(A7), T2
+	MOV	16(A7), T3
+	MOV	24(A7), T4
+	MOV	32(A7), T5
+	SRL	A5, T1, T1
+	SLL	A6, T2, A3
+	OR	A3, T1
+	MOV	T1, 0(A0)
+	SRL	A5, T2, T2
+	SLL	A6, T3, A3
+	OR	A3, T2
+	MOV	T2, 8(A0)
+	SRL	A5, T3, T3
+	SLL	A6, T4, A3
+	OR	A3, T3
+	MOV	T3, 16(A0)
+	SRL	A5, T4, T4
+	SLL	A6, T5, A3
+	OR	A3, T4
+	MOV	T4, 24(A0)
+	MOV	T5, T1
+	ADD	$32, A0
+	ADD	$32, A1
+	ADD	$32, A7
+	ADD	$-32, A2
+	SLTU	$32, A2, T0
+	BEQ	T0, ZERO, f_mloop32
+
+	// Do eight bytes at a time as long as there is room.
+f_mwordscheck:
+	SLTU	$8, A2, T0
+	BNE	T0, ZERO, f_narrow
+f_mwords:
+	MOV	8(A7), T2
+	SRL	A5, T1, T1
+	SLL	A6, T2, A3
+	OR	A3, T1
+	MOV	T1, (A0)
+	MOV	T2, T1
+	ADD	$8, A0
+	ADD	$8, A1
+	ADD	$8, A7
+	ADD	$-8, A2
+	SLTU	$8, A2, T0
+	BEQ	T0, ZERO, f_mwords
+	JMP	f_narrow
 
-	// Finish off the remaining partial word.
-	JMP 	f_outcheck
-f_out:
-	MOVB	(T1), T3
-	MOVB	T3, (T0)
-	ADD	$1, T0
-	ADD	$1, T1
-f_outcheck:
-	BNE	T1, T5, f_out
+	// Fewer than 16 bytes are left, at to and from.
+f_small:
+	OR	A0, A1, T0
+	AND	$7, T0
+	BEQ	T0, ZERO, small
 
+	// Copy the last n of 15 unrolled bytes, lowest first.
+	// Each byte takes eight bytes of code.
+f_narrow:
+	ADD	A2, A0
+	ADD	A2, A1
+	NORVC
+	AUIPC	$0, T0
+	SLL	$3, A2, T1
+	SUB	T1, T0
+	JALR	ZERO, (16+15*8)(T0)
+	MOVBU	-15(A1), T1
+	MOVB	T1, -15(A0)
+	MOVBU	-14(A1), T1
+	MOVB	T1, -14(A0)
+	MOVBU	-13(A1), T1
+	MOVB	T1, -13(A0)
+	MOVBU	-12(A1), T1
+	MOVB	T1, -12(A0)
+	MOVBU	-11(A1), T1
+	MOVB	T1, -11(A0)
+	MOVBU	-10(A1), T1
+	MOVB	T1, -10(A0)
+	MOVBU	-9(A1), T1
+	MOVB	T1, -9(A0)
+	MOVBU	-8(A1), T1
+	MOVB	T1, -8(A0)
+	MOVBU	-7(A1), T1
+	MOVB	T1, -7(A0)
+	MOVBU	-6(A1), T1
+	MOVB	T1, -6(A0)
+	MOVBU	-5(A1), T1
+	MOVB	T1, -5(A0)
+	MOVBU	-4(A1), T1
+	MOVB	T1, -4(A0)
+	MOVBU	-3(A1), T1
+	MOVB	T1, -3(A0)
+	MOVBU	-2(A1), T1
+	MOVB	T1, -2(A0)
+	MOVBU	-1(A1), T1
+	MOVB	T1, -1(A0)
+	RVC
+done:
+	RET
+
+	// Fewer than 16 bytes are left, and to and from are both
+	// eight-aligned. The copies below load everything before storing
+	// anything, so they serve overlapping copies in either direction.
+small:
+	NORVC
+	AUIPC	$0, T0
+	SLL	$2, A2, T1
+	ADD	T1, T0
+	JALR	ZERO, 16(T0)
+	JMP	done
+	JMP	small1
+	JMP	small2
+	JMP	small3
+	JMP	small4
+	JMP	small5
+	JMP	small6
+	JMP	small7
+	JMP	small8
+	JMP	small9
+	JMP	small10
+	JMP	small11
+	JMP	small12
+	JMP	small13
+	JMP	small14
+	JMP	small15
+	RVC
+small1:
+	MOVBU	0(A1), T0
+	MOVB	T0, 0(A0)
+	RET
+small2:
+	MOVHU	0(A1), T0
+	MOVH	T0, 0(A0)
+	RET
+small3:
+	MOVHU	0(A1), T0
+	MOVBU	2(A1), T1
+	MOVH	T0, 0(A0)
+	MOVB	T1, 2(A0)
+	RET
+small4:
+	MOVWU	0(A1), T0
+	MOVW	T0, 0(A0)
+	RET
+small5:
+	MOVWU	0(A1), T0
+	MOVBU	4(A1), T1
+	MOVW	T0, 0(A0)
+	MOVB	T1, 4(A0)
+	RET
+small6:
+	MOVWU	0(A1), T0
+	MOVHU	4(A1), T1
+	MOVW	T0, 0(A0)
+	MOVH	T1, 4(A0)
+	RET
+small7:
+	MOVWU	0(A1), T0
+	MOVHU	4(A1), T1
+	MOVBU	6(A1), T2
+	MOVW	T0, 0(A0)
+	MOVH	T1, 4(A0)
+	MOVB	T2, 6(A0)
+	RET
+small8:
+	MOV	0(A1), T0
+	MOV	T0, 0(A0)
+	RET
+small9:
+	MOV	0(A1), T0
+	MOVBU	8(A1), T1
+	MOV	T0, 0(A0)
+	MOVB	T1, 8(A0)
+	RET
+small10:
+	MOV	0(A1), T0
+	MOVHU	8(A1), T1
+	MOV	T0, 0(A0)
+	MOVH	T1, 8(A0)
+	RET
+small11:
+	MOV	0(A1), T0
+	MOVHU	8(A1), T1
+	MOVBU	10(A1), T2
+	MOV	T0, 0(A0)
+	MOVH	T1, 8(A0)
+	MOVB	T2, 10(A0)
+	RET
+small12:
+	MOV	0(A1), T0
+	MOVWU	8(A1), T1
+	MOV	T0, 0(A0)
+	MOVW	T1, 8(A0)
+	RET
+small13:
+	MOV	0(A1), T0
+	MOVWU	8(A1), T1
+	MOVBU	12(A1), T2
+	MOV	T0, 0(A0)
+	MOVW	T1, 8(A0)
+	MOVB	T2, 12(A0)
+	RET
+small14:
+	MOV	0(A1), T0
+	MOVWU	8(A1), T1
+	MOVHU	12(A1), T2
+	MOV	T0, 0(A0)
+	MOVW	T1, 8(A0)
+	MOVH	T2, 12(A0)
+	RET
+small15:
+	MOV	0(A1), T0
+	MOVWU	8(A1), T1
+	MOVHU	12(A1), T2
+	MOVBU	14(A1), T3
+	MOV	T0, 0(A0)
+	MOVW	T1, 8(A0)
+	MOVH	T2, 12(A0)
+	MOVB	T3, 14(A0)
 	RET
 
 b:
-	ADD	T0, T2, T4
-	// If less than eight bytes, do one byte at a time.
-	SLTU	$8, T2, T3
-	BNE	T3, ZERO, b_outcheck
+	SLTU	$16, A2, T0
+	BNE	T0, ZERO, b_small
+
+	ADD	A2, A0
+	ADD	A2, A1
+
+	XOR	A0, A1, T0
+	AND	$7, T0
+	BNE	T0, ZERO, b_mis
 
-	// Do one byte at a time until from+n is eight-aligned.
-	JMP	b_aligncheck
+	// to and from have the same alignment.
+	// Do one byte at a time until both ends are eight-aligned.
 b_align:
-	ADD	$-1, T4
-	ADD	$-1, T5
-	MOVB	(T5), T3
-	MOVB	T3, (T4)
-b_aligncheck:
-	AND	$7, T5, T3
-	BNE	T3, ZERO, b_align
+	AND	$7, A0, T0
+	BEQ	T0, ZERO, b_aligned
+	ADD	$-1, A0
+	ADD	$-1, A1
+	ADD	$-1, A2
+	MOVBU	(A1), T1
+	MOVB	T1, (A0)
+	JMP	b_align
+
+b_aligned:
+	// Do 64 bytes at a time as long as there is room.
+	SLTU	$64, A2, T0
+	BNE	T0, ZERO, b_wordscheck
+b_loop64:
+	MOV	-8(A1), T0
+	MOV	-16(A1), T1
+	MOV	-24(A1), T2
+	MOV	-32(A1), T3
+	MOV	-40(A1), T4
+	MOV	-48(A1), T5
+	MOV	-56(A1), A3
+	MOV	-64(A1), A4
+	MOV	T0, -8(A0)
+	MOV	T1, -16(A0)
+	MOV	T2, -24(A0)
+	MOV	T3, -32(A0)
+	MOV	T4, -40(A0)
+	MOV	T5, -48(A0)
+	MOV	A3, -56(A0)
+	MOV	A4, -64(A0)
+	ADD	$-64, A0
+	ADD	$-64, A1
+	ADD	$-64, A2
+	SLTU	$64, A2, T0
+	BEQ	T0, ZERO, b_loop64
 
 	// Do eight bytes at a time as long as there is room.
-	ADD	$7, T1, T6
-	JMP	b_wordscheck
-b_words:
-	ADD	$-8, T4
-	ADD	$-8, T5
-	MOV	(T5), T3
-	MOV	T3, (T4)
 b_wordscheck:
-	SLTU	T5, T6, T3
-	BNE	T3, ZERO, b_words
-
-	// Finish off the remaining partial word.
-	JMP	b_outcheck
-b_out:
-	ADD	$-1, T4
-	ADD	$-1, T5
-	MOVB	(T5), T3
-	MOVB	T3, (T4)
-b_outcheck:
-	BNE	T5, T1, b_out
+	SLTU	$8, A2, T0
+	BNE	T0, ZERO, b_rest
+b_words:
+	MOV	-8(A1), T0
+	MOV	T0, -8(A0)
+	ADD	$-8, A0
+	ADD	$-8, A1
+	ADD	$-8, A2
+	SLTU	$8, A2, T0
+	BEQ	T0, ZERO, b_words
+
+	// Fewer than eight bytes are left, just below A0 and A1, which
+	// are eight-aligned, so they hold no whole word.
+b_rest:
+	SUB	A2, A0
+	SUB	A2, A1
+	JMP	b_narrow
+
+	// to and from are misaligned relative to each other.
+	// Do one byte at a time until the end of to is eight-aligned.
+b_mis:
+	AND	$7, A0, T0
+	BEQ	T0, ZERO, b_misaligned
+	ADD	$-1, A0
+	ADD	$-1, A1
+	ADD	$-1, A2
+	MOVBU	(A1), T1
+	MOVB	T1, (A0)
+	JMP	b_mis
+
+b_misaligned:
+	// As for f_misaligned, but the aligned word at A7 holds the
+	// last k source bytes and the words are walked downward.
+	AND	$7, A1, T0
+	SLL	$3, T0, A5
+	SUB	A5, ZERO, A6
+	SUB	T0, A1, A7
+	MOV	(A7), T1
+
+	// Do 32 bytes at a time as long as there is room.
+	SLTU	$32, A2, T0
+	BNE	T0, ZERO, b_mwordscheck
+b_mloop32:
+	MOV	-8(A7), T2
+	MOV	-16(A7), T3
+	MOV	-24(A7), T4
+	MOV	-32(A7), T5
+	SLL	A6, T1, T1
+	SRL	A5, T2, A3
+	OR	A3, T1
+	MOV	T1, -8(A0)
+	SLL	A6, T2, T2
+	SRL	A5, T3, A3
+	OR	A3, T2
+	MOV	T2, -16(A0)
+	SLL	A6, T3, T3
+	SRL	A5, T4, A3
+	OR	A3, T3
+	MOV	T3, -24(A0)
+	SLL	A6, T4, T4
+	SRL	A5, T5, A3
+	OR	A3, T4
+	MOV	T4, -32(A0)
+	MOV	T5, T1
+	ADD	$-32, A0
+	ADD	$-32, A1
+	ADD	$-32, A7
+	ADD	$-32, A2
+	SLTU	$32, A2, T0
+	BEQ	T0, ZERO, b_mloop32
+
+	// Do eight bytes at a time as long as there is room.
+b_mwordscheck:
+	SLTU	$8, A2, T0
+	BNE	T0, ZERO, b_mrest
+b_mwords:
+	MOV	-8(A7), T2
+	SLL	A6, T1, T1
+	SRL	A5, T2, A3
+	OR	A3, T1
+	MOV	T1, -8(A0)
+	MOV	T2, T1
+	ADD	$-8, A0
+	ADD	$-8, A1
+	ADD	$-8, A7
+	ADD	$-8, A2
+	SLTU	$8, A2, T0
+	BEQ	T0, ZERO, b_mwords
+
+b_mrest:
+	SUB	A2, A0
+	SUB	A2, A1
+	JMP	b_narrow
+
+	// Fewer than 16 bytes are left, at to and from.
+b_small:
+	OR	A0, A1, T0
+	AND	$7, T0
+	BEQ	T0, ZERO, small
 
+	// Copy the first n of 15 unrolled bytes, highest first.
+b_narrow:
+	NORVC
+	AUIPC	$0, T0
+	SLL	$3, A2, T1
+	SUB	T1, T0
+	JALR	ZERO, (16+15*8)(T0)
+	MOVBU	14(A1), T1
+	MOVB	T1, 14(A0)
+	MOVBU	13(A1), T1
+	MOVB	T1, 13(A0)
+	MOVBU	12(A1), T1
+	MOVB	T1, 12(A0)
+	MOVBU	11(A1), T1
+	MOVB	T1, 11(A0)
+	MOVBU	10(A1), T1
+	MOVB	T1, 10(A0)
+	MOVBU	9(A1), T1
+	MOVB	T1, 9(A0)
+	MOVBU	8(A1), T1
+	MOVB	T1, 8(A0)
+	MOVBU	7(A1), T1
+	MOVB	T1, 7(A0)
+	MOVBU	6(A1), T1
+	MOVB	T1, 6(A0)
+	MOVBU	5(A1), T1
+	MOVB	T1, 5(A0)
+	MOVBU	4(A1), T1
+	MOVB	T1, 4(A0)
+	MOVBU	3(A1), T1
+	MOVB	T1, 3(A0)
+	MOVBU	2(A1), T1
+	MOVB	T1, 2(A0)
+	MOVBU	1(A1), T1
+	MOVB	T1, 1(A0)
+	MOVBU	0(A1), T1
+	MOVB	T1, 0(A0)
+	RVC
 	RET
@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"internal/race"
 	. "runtime"
+	"sync/atomic"
 	"testing"
+	"unsafe"
 )
 
 func TestMemmove(t *testing.T) {
@@ -235,6 +237,80 @@ func BenchmarkMemmoveUnalignedSrc(b *testing.B) {
 	})
 }
 
+// TestMemmoveAtomicity checks that memmove and memclr never write half
+// of an aligned pointer, which a concurrent garbage collector could
+// see.
+func TestMemmoveAtomicity(t *testing.T) {
+	if race.Enabled {
+		t.Skip("skip under the race detector -- this test is intentionally racy")
+	}
+	// The reader below spins without making calls, so the writer
+	// needs a P of its own.
+	defer GOMAXPROCS(GOMAXPROCS(2))
+
+	var x int
+
+	for _, backward := range []bool{true, false} {
+		for _, n := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 15, 25, 49} {
+			n := n
+
+			// test copying [N]*int.
+			sz := uintptr(n * PtrSize)
+			name := fmt.Sprint(sz)
+			if backward {
+				name += "-backward"
+			} else {
+				name += "-forward"
+			}
+			t.Run(name, func(t *testing.T) {
+				// Use overlapping src and dst, where there is room,
+				// to force forward/backward copy.
+				var s [100]*int
+				d := n - 1
+				if d == 0 {
+					d = 1
+				}
+				src := s[d : d+n]
+				dst := s[:n]
+				if backward {
+					src, dst = dst, src
+				}
+				for i := range src {
+					src[i] = &x
+				}
+				for i := range dst {
+					dst[i] = nil
+				}
+
+				var ready uint32
+				go func() {
+					sp := unsafe.Pointer(&src[0])
+					dp := unsafe.Pointer(&dst[0])
+					atomic.StoreUint32(&ready, 1)
+					for i := 0; i < 10000; i++ {
+						Memmove(dp, sp, sz)
+						MemclrNoHeapPointers(dp, sz)
+					}
+					atomic.StoreUint32(&ready, 2)
+				}()
+
+				for atomic.LoadUint32(&ready) == 0 {
+					Gosched()
+				}
+
+				for atomic.LoadUint32(&ready) != 2 {
+					for i := range dst {
+						p := dst[i]
+						if p != nil && p != &x {
+							t.Fatalf("got partially updated pointer %p at dst[%d], want either nil or %p", p, i, &x)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
 func TestMemclr(t *testing.T) {
 	size := 512
 	if testing.Short() {
@@ -221,7 +221,7 @@ func copyMIPS64x(w io.Writer) {
 func zeroRISCV(w io.Writer) {
 	// ZERO: always zero
 	// A0: ptr to start of memory to zero
-	// Use uncompressible instructions so that compiler does not need to track GORISCVRVC used to build runtime
+	// Use uncompressible instructions so that compiler does not need to track GORISCV used to build runtime
 	// uses T0 return to allow usage in leaf functions
 	fmt.Fprintln(w, "TEXT runtime·duffzero(SB), NOSPLIT, $-8-0")
 	for i := 256 - 8; i >= 0; i -= 8 {
@@ -234,7 +234,7 @@ func copyRISCV(w io.Writer) {
 	// A0: ptr to destination memory
 	// A1: ptr to source memory
 	// T6 aka TMP: not used by regalloc
-	// Use uncompressible instructions so that compiler does not need to track GORISCVRVC used to build runtime
+	// Use uncompressible instructions so that compiler does not need to track GORISCV used to build runtime
 	// uses T0 return to allow usage in leaf functions
 	fmt.Fprintln(w, "TEXT runtime·duffcopy(SB), NOSPLIT, $0-0")
 	for i := 256 - 8; i >= 0; i -= 8 {
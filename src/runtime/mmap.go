@@ -6,6 +6,7 @@
 // +build !solaris
 // +build !windows
 // +build !nacl
+// +build !none
 // +build !linux !amd64
 
 package runtime
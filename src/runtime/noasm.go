@@ -2,10 +2,10 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Routines that are implemented in assembly in asm_{amd64,386,arm,arm64,ppc64x,s390x}.s
+// Routines that are implemented in assembly in asm_{amd64,386,arm,arm64,ppc64x,riscv,s390x}.s
 // These routines have corresponding stubs in stubs_asm.go.
 
-// +build mips64 mips64le riscv
+// +build mips64 mips64le
 
 package runtime
 
@@ -0,0 +1,15 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import _ "unsafe" // for go:linkname
+
+// internal_cpu_riscvFeatures reports no extensions: FreeBSD has no
+// riscv_hwprobe equivalent, and the runtime does not read its auxv, so
+// only the extensions GORISCV assumes are used.
+//go:linkname internal_cpu_riscvFeatures internal/cpu.runtime_riscvFeatures
+func internal_cpu_riscvFeatures() (hwcap, ext0 uint64) {
+	return 0, 0
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+const (
+	_RISCV_HWPROBE_KEY_IMA_EXT_0 = 4
+)
+
+// riscvHWProbePair is a struct riscv_hwprobe from asm/hwprobe.h.
+type riscvHWProbePair struct {
+	key   int64
+	value uint64
+}
+
+// cpu_hwcap holds AT_HWCAP, which has a bit set for each single-letter
+// extension the CPU implements.
+var cpu_hwcap uintptr
+
+func archauxv(tag, val uintptr) {
+	switch tag {
+	case _AT_HWCAP: // CPU capability bit flags
+		cpu_hwcap = val
+	}
+	vdsoauxv(tag, val)
+}
+
+//go:noescape
+func riscv_hwprobe(pairs unsafe.Pointer, pairCount, cpuSetSize uintptr, cpus unsafe.Pointer, flags uint32) int32
+
+// internal_cpu_riscvFeatures returns AT_HWCAP and the
+// RISCV_HWPROBE_KEY_IMA_EXT_0 value that riscv_hwprobe reports for
+// all CPUs. The multi-letter extensions are only available from
+// riscv_hwprobe, which first appeared in Linux 6.4; on older kernels
+// ext0 is zero.
+//go:linkname internal_cpu_riscvFeatures internal/cpu.runtime_riscvFeatures
+func internal_cpu_riscvFeatures() (hwcap, ext0 uint64) {
+	pairs := [1]riscvHWProbePair{{key: _RISCV_HWPROBE_KEY_IMA_EXT_0}}
+	if riscv_hwprobe(unsafe.Pointer(&pairs[0]), 1, 0, nil, 0) == 0 && pairs[0].key != -1 {
+		ext0 = pairs[0].value
+	}
+	return uint64(cpu_hwcap), ext0
+}
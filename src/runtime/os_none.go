@@ -0,0 +1,376 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// There is no operating system underneath a GOOS=none program: it runs
+// on the boot hart alone, with the MMU off. Ms are not real threads but
+// contexts that take turns on that hart. They only switch when one of
+// them blocks or yields (semasleep, usleep, osyield), so runtime data
+// touched outside those calls needs no further protection. Goroutines
+// are still preempted by the timer interrupt; see trap.
+
+type mOS struct {
+	waitsema uint32 // semawakeups not yet consumed by semasleep
+	state    uint32 // _MNew, _MRunnable or _MBlocked
+	sema     bool   // blocked in semasleep rather than usleep
+	deadline int64  // nanotime at which a blocked M wakes anyway, or -1
+	ctx      threadContext
+}
+
+const (
+	_MNew      = iota // created but not yet started by newosproc
+	_MRunnable        // running or ready to run
+	_MBlocked         // waiting for a semawakeup or a deadline
+)
+
+// Signal numbers recorded in g.sig for faults. They follow Unix so that
+// tracebacks read the same as elsewhere.
+const (
+	_NSIG    = 32
+	_SIGILL  = 4
+	_SIGTRAP = 5
+	_SIGBUS  = 10
+	_SIGSEGV = 11
+)
+
+type sigset struct{}
+
+// switchcontext saves the callee's return context in from and resumes
+// the M whose context is in to. It returns when some other M switches
+// back to from.
+//go:noescape
+func switchcontext(from, to *threadContext)
+
+// mstart_none is where a new M's context first resumes. It calls
+// mstart on the M's g0 stack.
+func mstart_none()
+
+//go:noescape
+func write(fd uintptr, p unsafe.Pointer, n int32) int32
+
+// Stubs so tests can link correctly. These should never be called.
+func open(name *byte, mode, perm int32) int32 { return -1 }
+func closefd(fd int32) int32                  { return -1 }
+func read(fd int32, p unsafe.Pointer, n int32) int32 {
+	return -1
+}
+
+// The console that package syscall provides as descriptors 0, 1 and 2.
+
+//go:linkname syscall_runtime_consoleWrite syscall.runtime_consoleWrite
+func syscall_runtime_consoleWrite(p unsafe.Pointer, n int32) int32 {
+	return write(1, p, n)
+}
+
+//go:linkname syscall_runtime_exit syscall.runtime_exit
+func syscall_runtime_exit(code int32) {
+	exit(code)
+}
+
+//go:linkname os_sigpipe os.sigpipe
+func os_sigpipe() {
+	throw("too many writes on closed pipe")
+}
+
+func sigpanic() {
+	g := getg()
+	if !canpanic(g) {
+		throw("unexpected signal during runtime execution")
+	}
+
+	switch g.sig {
+	case _SIGBUS, _SIGSEGV:
+		if g.sigcode1 < 0x1000 || g.paniconfault {
+			panicmem()
+		}
+		print("unexpected fault address ", hex(g.sigcode1), "\n")
+		throw("fault")
+	}
+	throw("unexpected trap")
+}
+
+// Called to initialize a new m (including the bootstrap m).
+// Called on the parent thread (main thread in case of bootstrap), can allocate memory.
+func mpreinit(mp *m) {
+	// Traps run on the gsignal stack of whichever M was interrupted.
+	mp.gsignal = malg(32 * 1024)
+	mp.gsignal.m = mp
+}
+
+//go:nosplit
+func msigsave(mp *m) {
+}
+
+//go:nosplit
+func msigrestore(sigmask sigset) {
+}
+
+//go:nosplit
+func sigblock() {
+}
+
+// Called to initialize a new m (including the bootstrap m).
+// Called on the new thread, cannot allocate memory.
+func minit() {
+	// Start the preemption tick; later Ms just restart it.
+	setTimer(nanotime() + forcePreemptNS)
+	enableInterrupts()
+}
+
+// Called from dropm to undo the effect of an minit.
+func unminit() {
+}
+
+func osinit() {
+	checkBootMode()
+	ncpu = 1
+	physPageSize = 4096
+	getg().m.state = _MRunnable
+}
+
+func signame(sig uint32) string {
+	switch sig {
+	case _SIGILL:
+		return "SIGILL: illegal instruction"
+	case _SIGTRAP:
+		return "SIGTRAP: trace trap"
+	case _SIGBUS:
+		return "SIGBUS: bus error"
+	case _SIGSEGV:
+		return "SIGSEGV: segmentation violation"
+	}
+	return ""
+}
+
+func crash() {
+	*(*int32)(nil) = 0
+}
+
+func getRandomData(r []byte) {
+	// There is no entropy source we can count on, so fall back to
+	// hashing the timer.
+	extendRandom(r, 0)
+}
+
+// bootfdt is the address of the flattened device tree that the boot
+// ROM or SBI firmware passed in a1, recorded by rt0.
+var bootfdt uintptr
+
+// The words of the kernel command line, the bootargs property of the
+// device tree's /chosen node, become the program's arguments. There is
+// no environment. sysargs runs before the heap exists and copies them
+// here, because the device tree may lie in memory the runtime reuses.
+var (
+	bootargs [1024]byte
+	bootargv [64]*byte
+)
+
+func sysargs(argc0 int32, argv0 **byte) {
+	s := fdtBootargs(bootfdt)
+	if len(s) >= len(bootargs) {
+		s = s[:len(bootargs)-1]
+	}
+	n := 0
+	for i := 0; i < len(s); {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i == len(s) || n == len(bootargv)-2 {
+			break
+		}
+		bootargv[n] = &bootargs[i]
+		n++
+		for i < len(s) && s[i] != ' ' {
+			bootargs[i] = s[i]
+			i++
+		}
+	}
+	if n == 0 {
+		copy(bootargs[:], "a.out")
+		bootargv[0] = &bootargs[0]
+		n = 1
+	}
+	// bootargv[n] and bootargv[n+1] stay nil to end argv and the
+	// (empty) environment.
+	argc = int32(n)
+	argv = &bootargv[0]
+}
+
+// fdtBootargs returns the bootargs property of /chosen in the device
+// tree at fdt, without its trailing NUL, or nil.
+func fdtBootargs(fdt uintptr) []byte {
+	if fdt < ramBase || fdt >= ramBase+ramSize-40 || fdt&3 != 0 || fdt32(fdt) != 0xd00dfeed {
+		return nil
+	}
+	p := fdt + uintptr(fdt32(fdt+8))     // off_dt_struct
+	strs := fdt + uintptr(fdt32(fdt+12)) // off_dt_strings
+	end := fdt + uintptr(fdt32(fdt+4))   // totalsize
+	depth := 0
+	chosen := false
+	for p < end {
+		tok := fdt32(p)
+		p += 4
+		switch tok {
+		case 1: // FDT_BEGIN_NODE
+			name := gostringnocopy((*byte)(unsafe.Pointer(p)))
+			depth++
+			if depth == 2 {
+				chosen = name == "chosen"
+			}
+			p = round(p+uintptr(len(name))+1, 4)
+		case 2: // FDT_END_NODE
+			depth--
+		case 3: // FDT_PROP
+			n := uintptr(fdt32(p))
+			name := gostringnocopy((*byte)(unsafe.Pointer(strs + uintptr(fdt32(p+4)))))
+			p += 8
+			if chosen && depth == 2 && name == "bootargs" && n > 0 {
+				return (*[1 << 20]byte)(unsafe.Pointer(p))[:n-1 : n-1]
+			}
+			p = round(p+n, 4)
+		case 4: // FDT_NOP
+		default: // FDT_END or garbage
+			return nil
+		}
+	}
+	return nil
+}
+
+// fdt32 reads a big-endian device tree word.
+func fdt32(p uintptr) uint32 {
+	b := (*[4]byte)(unsafe.Pointer(p))
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func goenvs() {
+	goenvs_unix()
+}
+
+func initsig(preinit bool) {
+}
+
+// May run with m.p==nil, so write barriers are not allowed.
+//go:nowritebarrier
+func newosproc(mp *m, stk unsafe.Pointer) {
+	mp.ctx.pc = funcPC(mstart_none)
+	mp.ctx.sp = uintptr(stk)
+	mp.ctx.g = uintptr(unsafe.Pointer(mp.g0))
+	mp.state = _MRunnable
+}
+
+//go:nosplit
+func semacreate(mp *m) {
+}
+
+//go:nosplit
+func semasleep(ns int64) int32 {
+	mp := getg().m
+	deadline := int64(-1)
+	if ns >= 0 {
+		deadline = nanotime() + ns
+	}
+	for mp.waitsema == 0 {
+		if deadline >= 0 && nanotime() >= deadline {
+			return -1
+		}
+		mp.state = _MBlocked
+		mp.sema = true
+		mp.deadline = deadline
+		mswitch()
+	}
+	mp.waitsema--
+	return 0
+}
+
+//go:nosplit
+func semawakeup(mp *m) {
+	mp.waitsema++
+}
+
+//go:nosplit
+func usleep(us uint32) {
+	mp := getg().m
+	deadline := nanotime() + int64(us)*1000
+	for nanotime() < deadline {
+		mp.state = _MBlocked
+		mp.sema = false
+		mp.deadline = deadline
+		mswitch()
+	}
+}
+
+//go:nosplit
+func osyield() {
+	mswitch()
+}
+
+// mcanrun reports whether mp can be resumed at time now.
+//go:nosplit
+func mcanrun(mp *m, now int64) bool {
+	switch mp.state {
+	case _MRunnable:
+		return true
+	case _MBlocked:
+		return mp.sema && mp.waitsema > 0 || mp.deadline >= 0 && now >= mp.deadline
+	}
+	return false
+}
+
+// mswitch hands the hart to the next M after the current one in allm
+// that can run, and returns once the current M is resumed. If no M can
+// run, it idles until the earliest deadline.
+//go:nosplit
+func mswitch() {
+	me := getg().m
+	for {
+		now := nanotime()
+		wake := int64(-1)
+		mp := me
+		for {
+			if mp = mp.alllink; mp == nil {
+				mp = allm
+			}
+			if mcanrun(mp, now) {
+				mp.state = _MRunnable
+				if mp != me {
+					switchcontext(&me.ctx, &mp.ctx)
+				}
+				return
+			}
+			if mp.state == _MBlocked && mp.deadline >= 0 && (wake < 0 || mp.deadline < wake) {
+				wake = mp.deadline
+			}
+			if mp == me {
+				break
+			}
+		}
+		if wake < 0 {
+			throw("all threads are blocked")
+		}
+		idle(wake)
+	}
+}
+
+func memlimit() uintptr {
+	// mallocinit starts the arena a little past the end of the
+	// image, as below, and fits its bitmap and spans array in
+	// just over the limit we return; allow for both.
+	start := round(firstmoduledata.end+(1<<18), 1<<20)
+	if start+1<<20 > sysPoolStart {
+		return 0
+	}
+	n := sysPoolStart - start
+	return n - n/256 - 64<<10
+}
+
+func madvise(addr unsafe.Pointer, n uintptr, flags int32) {}
+func munmap(addr unsafe.Pointer, n uintptr)               {}
+func resetcpuprofiler(hz int32)                           {}
+func sigdisable(uint32)                                   {}
+func sigenable(uint32)                                    {}
+func sigignore(uint32)                                    {}
+func closeonexec(int32)                                   {}
@@ -0,0 +1,208 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+import "unsafe"
+
+// The memory map of QEMU's virt machine (qemu-system-riscv64 -machine
+// virt). Other boards need these changed to match, along with the link
+// address in cmd/link/internal/riscv.
+const (
+	ramBase       = 0x80000000
+	ramSize       = 128 << 20
+	uartBase      = 0x10000000 // NS16550A
+	clintBase     = 0x02000000
+	finisherBase  = 0x00100000 // SiFive test device
+	timebaseFreq  = 10000000   // rate of the time CSR, in Hz
+	clintMtimecmp = clintBase + 0x4000
+)
+
+const (
+	finisherFail = 0x3333
+	finisherPass = 0x5555
+)
+
+// SBI extensions, for supervisor mode.
+const (
+	sbiTime = 0x54494d45 // set_timer is function 0
+	sbiSRST = 0x53525354 // system_reset is function 0
+)
+
+// CSR bits.
+const (
+	mstatusMIE = 1 << 3
+	mieMTIE    = 1 << 7
+	sstatusSIE = 1 << 1
+	sieSTIE    = 1 << 5
+
+	causeInterrupt = 1 << 63
+	irqSTimer      = 5
+	irqMTimer      = 7
+
+	excInstMisaligned  = 0
+	excInstFault       = 1
+	excIllegalInst     = 2
+	excBreakpoint      = 3
+	excLoadMisaligned  = 4
+	excLoadFault       = 5
+	excStoreMisaligned = 6
+	excStoreFault      = 7
+)
+
+// threadContext is what switchcontext keeps for an M that is not
+// running. Go code treats every other register as clobbered by a call.
+type threadContext struct {
+	pc uintptr
+	sp uintptr
+	g  uintptr
+}
+
+// supervisor is set by rt0 when the program runs in supervisor mode
+// under SBI firmware rather than in machine mode. Only the trap CSRs,
+// the interrupt enables, the timer and exit differ.
+var supervisor bool
+
+// sbiEntry is set by rt0 when the program was entered through
+// _rt0_riscv_none_sbi, the supervisor-mode entry point.
+var sbiEntry bool
+
+// checkBootMode stops the program if it was linked for one mode and
+// started in the other.
+func checkBootMode() {
+	if supervisor == sbiEntry {
+		return
+	}
+	if supervisor {
+		print("runtime: started in supervisor mode; link with -ldflags='-E _rt0_riscv_none_sbi -T 0x80200000' to run under SBI firmware\n")
+	} else {
+		print("runtime: started in machine mode, but linked with -E _rt0_riscv_none_sbi for SBI firmware\n")
+	}
+	// This is too early to throw: the heap and the signal stack
+	// a fault would need are not set up yet.
+	exit(2)
+}
+
+// trapframe holds the registers saved by trapvector or strapvector.
+type trapframe struct {
+	x     [32]uint64
+	f     [32]uint64
+	pc    uint64 // mepc or sepc
+	cause uint64 // mcause or scause
+	tval  uint64 // mtval or stval
+}
+
+// trapvector is the machine-mode trap entry, and strapvector the
+// supervisor-mode one; rt0 installs one in mtvec or stvec.
+func trapvector()
+func strapvector()
+
+//go:noescape
+func sbicall(ext, fid, a0, a1 uintptr) int
+
+func enableInterrupts()
+func disableInterrupts()
+func wfi()
+
+//go:nosplit
+func nanotime() int64 {
+	return cputicks() * (1e9 / timebaseFreq)
+}
+
+//go:nosplit
+func exit(code int32) {
+	// On QEMU the test device powers off the machine, with the
+	// exit status in the upper half of a failure code. Elsewhere
+	// the store goes nowhere; SBI firmware is asked to shut down,
+	// and failing that the hart sleeps for good.
+	v := uint32(finisherPass)
+	if code != 0 {
+		v = uint32(code)<<16 | finisherFail
+	}
+	*(*uint32)(unsafe.Pointer(uintptr(finisherBase))) = v
+	if supervisor {
+		reason := uintptr(0) // no reason
+		if code != 0 {
+			reason = 1 // system failure
+		}
+		sbicall(sbiSRST, 0, 0, reason)
+	}
+	disableInterrupts()
+	for {
+		wfi()
+	}
+}
+
+// setTimer arranges for a timer interrupt at nanotime when.
+//go:nosplit
+func setTimer(when int64) {
+	t := uint64(when / (1e9 / timebaseFreq))
+	if supervisor {
+		// The CLINT belongs to the firmware.
+		sbicall(sbiTime, 0, uintptr(t), 0)
+		return
+	}
+	*(*uint64)(unsafe.Pointer(uintptr(clintMtimecmp))) = t
+}
+
+// idle sleeps the hart until nanotime until, or until an interrupt.
+//go:nosplit
+func idle(until int64) {
+	// With interrupts off, a timer that fires between the check
+	// and the wfi still wakes it.
+	disableInterrupts()
+	if nanotime() < until {
+		setTimer(until)
+		wfi()
+	}
+	enableInterrupts()
+}
+
+// trap is called by trapvector on the gsignal stack of the interrupted
+// M, with gp the interrupted goroutine. Whatever it leaves in tf is
+// resumed.
+//go:nowritebarrierrec
+func trap(tf *trapframe, gp *g) {
+	if tf.cause&causeInterrupt != 0 {
+		if irq := tf.cause &^ causeInterrupt; irq == irqMTimer || irq == irqSTimer {
+			// Preempt the running goroutine, as sysmon would
+			// do for one that has run for too long.
+			setTimer(nanotime() + forcePreemptNS)
+			if cg := gp.m.curg; cg != nil {
+				cg.preempt = true
+				cg.stackguard0 = stackPreempt
+			}
+		}
+		return
+	}
+
+	var sig uint32
+	switch tf.cause {
+	case excInstMisaligned, excLoadMisaligned, excStoreMisaligned:
+		sig = _SIGBUS
+	case excIllegalInst:
+		sig = _SIGILL
+	case excBreakpoint:
+		sig = _SIGTRAP
+	default:
+		sig = _SIGSEGV
+	}
+
+	// Make it look like the faulting instruction called sigpanic,
+	// which panics or throws on gp's own stack.
+	c := &sigctxt{tf}
+	gp.sig = sig
+	gp.sigcode0 = uintptr(tf.cause)
+	gp.sigcode1 = c.fault()
+	gp.sigpc = c.sigpc()
+	c.preparePanic(sig, gp)
+}
+
+// internal_cpu_riscvFeatures reports no extensions: there is no
+// standard way to probe for them on bare metal, so only the
+// extensions GORISCV assumes are used.
+//go:linkname internal_cpu_riscvFeatures internal/cpu.runtime_riscvFeatures
+func internal_cpu_riscvFeatures() (hwcap, ext0 uint64) {
+	return 0, 0
+}
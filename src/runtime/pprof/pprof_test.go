@@ -89,6 +89,125 @@ func TestCPUProfileMultithreaded(t *testing.T) {
 	})
 }
 
+// cpuHogLeaf has a frame but makes no calls, and cpuHogMid calls it
+// from a known place. Samples in cpuHogLeaf check that tracebacks from
+// a signal find the caller whether or not the frame is set up yet.
+//go:noinline
+func cpuHogLeaf(n int) int {
+	var buf [64]int
+	for i := 0; i < 1e4; i++ {
+		buf[(i+n)%len(buf)] += i
+	}
+	return buf[n%len(buf)]
+}
+
+//go:noinline
+func cpuHogMid() {
+	foo := salt1
+	for i := 0; i < 10; i++ {
+		foo += cpuHogLeaf(i + foo)
+	}
+	salt1 = foo
+}
+
+func TestCPUProfileCallTree(t *testing.T) {
+	var prof bytes.Buffer
+	if err := StartCPUProfile(&prof); err != nil {
+		t.Fatal(err)
+	}
+	dur := 5 * time.Second
+	if testing.Short() {
+		dur = 500 * time.Millisecond
+	}
+	cpuHogger(cpuHogMid, dur)
+	StopCPUProfile()
+
+	var leaf uintptr
+	parseProfile(t, prof.Bytes(), func(count uintptr, stk []uintptr) {
+		for i, pc := range stk {
+			f := runtime.FuncForPC(pc)
+			if f == nil || !strings.HasSuffix(f.Name(), ".cpuHogLeaf") {
+				continue
+			}
+			leaf += count
+			var caller string
+			if i+1 < len(stk) {
+				if f := runtime.FuncForPC(stk[i+1]); f != nil {
+					caller = f.Name()
+				}
+			}
+			if !strings.HasSuffix(caller, ".cpuHogMid") {
+				t.Errorf("cpuHogLeaf called from %q, want cpuHogMid; stack:", caller)
+				for _, pc := range stk {
+					if f := runtime.FuncForPC(pc); f != nil {
+						t.Logf("\t%#x %s", pc, f.Name())
+					} else {
+						t.Logf("\t%#x ?", pc)
+					}
+				}
+			}
+			break
+		}
+	})
+	if leaf == 0 {
+		t.Skip("no samples in cpuHogLeaf")
+	}
+}
+
+//go:noinline
+func timeHog() {
+	for i := 0; i < 100; i++ {
+		time.Now()
+	}
+}
+
+// TestCPUProfileVDSO checks that samples taken while nanotime or
+// walltime is running in the vDSO keep the Go stack that led there,
+// instead of being recorded as _ExternalCode.
+func TestCPUProfileVDSO(t *testing.T) {
+	if runtime.GOOS != "linux" || runtime.GOARCH != "riscv" {
+		t.Skipf("skipping on %s/%s, which does not trace vDSO calls", runtime.GOOS, runtime.GOARCH)
+	}
+	var prof bytes.Buffer
+	if err := StartCPUProfile(&prof); err != nil {
+		t.Fatal(err)
+	}
+	dur := 5 * time.Second
+	if testing.Short() {
+		dur = 500 * time.Millisecond
+	}
+	cpuHogger(timeHog, dur)
+	StopCPUProfile()
+
+	var inTime uintptr
+	parseProfile(t, prof.Bytes(), func(count uintptr, stk []uintptr) {
+		names := make([]string, len(stk))
+		for i, pc := range stk {
+			names[i] = "?"
+			if f := runtime.FuncForPC(pc); f != nil {
+				names[i] = f.Name()
+			}
+		}
+		switch names[0] {
+		case "runtime.nanotime", "runtime.walltime":
+			inTime += count
+			for _, name := range names {
+				if strings.HasSuffix(name, ".TestCPUProfileVDSO") {
+					return
+				}
+			}
+			t.Errorf("%s sample does not reach the test; stack: %v", names[0], names)
+		default:
+			if len(names) == 2 && names[1] == "runtime._ExternalCode" {
+				t.Errorf("sample at %#x recorded as external code", stk[0])
+			}
+		}
+	})
+	if inTime == 0 {
+		t.Skip("no samples in nanotime or walltime")
+	}
+}
+
 func parseProfile(t *testing.T, valBytes []byte, f func(uintptr, []uintptr)) {
 	p, err := profile.Parse(bytes.NewReader(valBytes))
 	if err != nil {
@@ -3314,6 +3314,21 @@ func sigprof(pc, sp, lr uintptr, gp *g, mp *m) {
 				gcUnlockStackBarriers(mp.libcallg.ptr())
 			}
 		}
+		if n == 0 && gp != nil && mp.vdsoSP != 0 {
+			// vDSO call, i.e. nanotime or walltime on linux/riscv.
+			// Collect Go stack that leads to the call.
+			var flags uint
+			locked := gp.m.curg != nil && gcTryLockStackBarriers(gp.m.curg)
+			if locked {
+				flags |= _TraceJumpStack
+			}
+			if gp != gp.m.curg || locked {
+				n = gentraceback(mp.vdsoPC, mp.vdsoSP, 0, gp, 0, &stk[0], len(stk), nil, nil, flags)
+			}
+			if locked {
+				gcUnlockStackBarriers(gp.m.curg)
+			}
+		}
 		if n == 0 {
 			// If all of the above has failed, account it against abstract "System" or "GC".
 			n = 2
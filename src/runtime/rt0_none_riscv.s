@@ -0,0 +1,75 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// The linker puts the entry point at the start of the image. The
+// default, _rt0_riscv_none, is for machine mode: the boot ROM enters it
+// at the start of RAM with the MMU off, on every hart, and all but hart 0
+// park for good. _rt0_riscv_none_sbi, chosen with
+// -ldflags='-E _rt0_riscv_none_sbi -T 0x80200000', is for supervisor
+// mode: SBI firmware enters it with the MMU off, on the boot hart alone.
+// Either way a1 holds the device tree. rt0 finds out which mode it was
+// really entered in, and osinit stops if it is the wrong one.
+TEXT _rt0_riscv_none(SB),NOSPLIT,$-8
+	MOV	ZERO, T3
+	JMP	rt0<>(SB)
+
+TEXT _rt0_riscv_none_sbi(SB),NOSPLIT,$-8
+	MOV	$1, T3
+	JMP	rt0<>(SB)
+
+TEXT rt0<>(SB),NOSPLIT,$-8
+	// Reading mhartid traps in supervisor mode, to smode<>. stvec
+	// can be written in machine mode too.
+	MOV	$smode<>(SB), T0
+	WORD	$0x10529073	// csrw stvec, t0
+	WORD	$0xf14022f3	// csrr t0, mhartid
+	BNE	T0, ZERO, park
+
+	// Turn on the FPU (mstatus.FS = Initial) and install the trap
+	// vector. Interrupts stay off until minit.
+	MOV	$0x2000, T0
+	WORD	$0x3002a073	// csrs mstatus, t0
+	MOV	$runtime·trapvector(SB), T0
+	WORD	$0x30529073	// csrw mtvec, t0
+	MOV	ZERO, T2
+	JMP	bss<>(SB)
+
+park:
+	WFI
+	JMP	park
+
+TEXT smode<>(SB),NOSPLIT,$-8
+	// The same through sstatus and stvec.
+	MOV	$0x2000, T0
+	WORD	$0x1002a073	// csrs sstatus, t0
+	MOV	$runtime·strapvector(SB), T0
+	WORD	$0x10529073	// csrw stvec, t0
+	MOV	$1, T2
+	JMP	bss<>(SB)
+
+TEXT bss<>(SB),NOSPLIT,$-8
+	// Nothing else clears .bss and .noptrbss, which are adjacent.
+	MOV	$runtime·bss(SB), T0
+	MOV	$runtime·enoptrbss(SB), T1
+clear:
+	BGEU	T0, T1, cleared
+	MOV	ZERO, 0(T0)
+	ADD	$8, T0
+	JMP	clear
+
+cleared:
+	MOVB	T2, runtime·supervisor(SB)
+	MOVB	T3, runtime·sbiEntry(SB)
+	// sysargs finds the arguments in the device tree.
+	MOV	A1, runtime·bootfdt(SB)
+
+	// rt0_go takes the 64kB below SP for g0.
+	MOV	$bootstack<>+(68*1024)(SB), X2
+	MOV	ZERO, A0
+	MOV	ZERO, A1
+	JMP	runtime·rt0_go(SB)
+
+GLOBL bootstack<>(SB), NOPTR, $(68*1024)
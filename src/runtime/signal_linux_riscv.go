@@ -60,7 +60,7 @@ func (c *sigctxt) sigaddr() uint64 { return c.info.si_addr }
 func (c *sigctxt) set_pc(x uint64) { c.regs().sc_regs.pc = x }
 func (c *sigctxt) set_ra(x uint64) { c.regs().sc_regs.ra = x }
 func (c *sigctxt) set_sp(x uint64) { c.regs().sc_regs.sp = x }
-func (c *sigctxt) set_gp(x uint64) { c.regs().sc_regs.gp = x }
+func (c *sigctxt) set_s11(x uint64) { c.regs().sc_regs.s11 = x }
 
 func (c *sigctxt) set_sigcode(x uint32) { c.info.si_code = int32(x) }
 func (c *sigctxt) set_sigaddr(x uint64) {
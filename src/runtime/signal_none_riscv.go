@@ -0,0 +1,58 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// sigctxt wraps the trapframe saved by trapvector, so that the code
+// in signal_riscv.go can treat a trap like a signal.
+type sigctxt struct {
+	tf *trapframe
+}
+
+//go:nosplit
+//go:nowritebarrierrec
+func (c *sigctxt) regs() *[32]uint64 { return &c.tf.x }
+
+func (c *sigctxt) ra() uint64  { return c.regs()[1] }
+func (c *sigctxt) sp() uint64  { return c.regs()[2] }
+func (c *sigctxt) gp() uint64  { return c.regs()[3] }
+func (c *sigctxt) tp() uint64  { return c.regs()[4] }
+func (c *sigctxt) t0() uint64  { return c.regs()[5] }
+func (c *sigctxt) t1() uint64  { return c.regs()[6] }
+func (c *sigctxt) t2() uint64  { return c.regs()[7] }
+func (c *sigctxt) s0() uint64  { return c.regs()[8] }
+func (c *sigctxt) s1() uint64  { return c.regs()[9] }
+func (c *sigctxt) a0() uint64  { return c.regs()[10] }
+func (c *sigctxt) a1() uint64  { return c.regs()[11] }
+func (c *sigctxt) a2() uint64  { return c.regs()[12] }
+func (c *sigctxt) a3() uint64  { return c.regs()[13] }
+func (c *sigctxt) a4() uint64  { return c.regs()[14] }
+func (c *sigctxt) a5() uint64  { return c.regs()[15] }
+func (c *sigctxt) a6() uint64  { return c.regs()[16] }
+func (c *sigctxt) a7() uint64  { return c.regs()[17] }
+func (c *sigctxt) s2() uint64  { return c.regs()[18] }
+func (c *sigctxt) s3() uint64  { return c.regs()[19] }
+func (c *sigctxt) s4() uint64  { return c.regs()[20] }
+func (c *sigctxt) s5() uint64  { return c.regs()[21] }
+func (c *sigctxt) s6() uint64  { return c.regs()[22] }
+func (c *sigctxt) s7() uint64  { return c.regs()[23] }
+func (c *sigctxt) s8() uint64  { return c.regs()[24] }
+func (c *sigctxt) s9() uint64  { return c.regs()[25] }
+func (c *sigctxt) s10() uint64 { return c.regs()[26] }
+func (c *sigctxt) s11() uint64 { return c.regs()[27] }
+func (c *sigctxt) t3() uint64  { return c.regs()[28] }
+func (c *sigctxt) t4() uint64  { return c.regs()[29] }
+func (c *sigctxt) t5() uint64  { return c.regs()[30] }
+func (c *sigctxt) t6() uint64  { return c.regs()[31] }
+
+//go:nosplit
+//go:nowritebarrierrec
+func (c *sigctxt) pc() uint64 { return c.tf.pc }
+
+func (c *sigctxt) sigaddr() uint64 { return c.tf.tval }
+
+func (c *sigctxt) set_pc(x uint64)  { c.tf.pc = x }
+func (c *sigctxt) set_ra(x uint64)  { c.regs()[1] = x }
+func (c *sigctxt) set_sp(x uint64)  { c.regs()[2] = x }
+func (c *sigctxt) set_s11(x uint64) { c.regs()[27] = x }
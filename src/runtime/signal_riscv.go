@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// +build linux
+// +build freebsd linux none
 // +build riscv
 
 package runtime
@@ -85,6 +85,6 @@ func (c *sigctxt) preparePanic(sig uint32, gp *g) {
 	}
 
 	// In case we are panicking from external C code
-	c.set_gp(uint64(uintptr(unsafe.Pointer(gp))))
+	c.set_s11(uint64(uintptr(unsafe.Pointer(gp))))
 	c.set_pc(uint64(funcPC(sigpanic)))
 }
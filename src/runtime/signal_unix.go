@@ -200,9 +200,10 @@ func sigtrampgo(sig uint32, info *siginfo, ctx unsafe.Pointer) {
 	if sigfwdgo(sig, info, ctx) {
 		return
 	}
-	g := getg()
+	c := &sigctxt{info, ctx}
+	g := sigFetchG(c)
+	setg(g)
 	if g == nil {
-		c := &sigctxt{info, ctx}
 		if sig == _SIGPROF {
 			sigprofNonGoPC(c.sigpc())
 			return
@@ -252,7 +253,6 @@ func sigtrampgo(sig uint32, info *siginfo, ctx unsafe.Pointer) {
 	}
 
 	setg(g.m.gsignal)
-	c := &sigctxt{info, ctx}
 	c.fixsigcode(sig)
 	sighandler(sig, info, ctx, g)
 	setg(g)
@@ -261,6 +261,27 @@ func sigtrampgo(sig uint32, info *siginfo, ctx unsafe.Pointer) {
 	}
 }
 
+// sigFetchG fetches the value of g safely when running in a signal
+// handler. On riscv the vDSO may clobber g while it runs, so nanotime
+// and walltime save g at the bottom of the gsignal stack first.
+//go:nosplit
+func sigFetchG(c *sigctxt) *g {
+	switch GOARCH {
+	case "riscv":
+		if !iscgo && inVDSOPage(c.sigpc()) {
+			// With cgo, sigtramp has already loaded g from TLS.
+			// Otherwise fetch the g nanotime or walltime saved.
+			sp := getcallersp(unsafe.Pointer(&c))
+			s := spanOf(sp)
+			if s != nil && s.state == _MSpanStack && s.base() < sp && sp < s.base()+s.npages<<_PageShift {
+				return *(**g)(unsafe.Pointer(s.base()))
+			}
+			return nil
+		}
+	}
+	return getg()
+}
+
 // sigpanic turns a synchronous signal into a run-time panic.
 // If the signal handler sees a synchronous panic, it arranges the
 // stack to look like the function where the signal occurred called
@@ -529,7 +550,7 @@ func sigfwdgo(sig uint32, info *siginfo, ctx unsafe.Pointer) bool {
 	// Determine if the signal occurred inside Go code. We test that:
 	//   (1) we were in a goroutine (i.e., m.curg != nil), and
 	//   (2) we weren't in CGO.
-	g := getg()
+	g := sigFetchG(c)
 	if g != nil && g.m != nil && g.m.curg != nil && !g.m.incgo {
 		return false
 	}
@@ -36,6 +36,7 @@
 #define SYS_openat		56
 #define SYS_pselect6		72
 #define SYS_read		63
+#define SYS_riscv_hwprobe	258
 #define SYS_rt_sigaction	134
 #define SYS_rt_sigprocmask	135
 #define SYS_rt_sigreturn	139
@@ -200,26 +201,87 @@ TEXT runtime·mincore(SB),NOSPLIT,$-8-28
 	MOVW	A0, ret+24(FP)
 	RET
 
+// VDSO_CALL calls the vDSO function in A7 with arguments A0 and A1,
+// pointing A1 at a 16-byte timespec on the system stack first. C code
+// needs more stack than a goroutine may have, so unless we are already
+// on g0 or the signal stack, switch to g0's stack. S2 (the old SP), S3,
+// S4 and g (S11) are callee-saved in the C ABI.
+//
+// T2 holds the caller's SP. While the vDSO runs, m.vdsoPC and m.vdsoSP
+// let sigprof trace the Go stack that led to the call; their old values
+// are kept at 24(X2) and 32(X2) in case this call interrupted another.
+// The vDSO may use S11 as long as it restores it before returning, so g
+// is also stored at the bottom of the gsignal stack, where sigFetchG
+// finds it if a signal arrives in the vDSO.
+#define VDSO_CALL \
+	MOV	g_m(g), S3 \
+	MOV	m_vdsoPC(S3), T0 \
+	MOV	T0, 24(X2) \
+	MOV	m_vdsoSP(S3), T0 \
+	MOV	T0, 32(X2) \
+	MOV	RA, m_vdsoPC(S3) \
+	MOV	T2, m_vdsoSP(S3) \
+	MOV	X2, S2 \
+	MOV	m_curg(S3), T1 \
+	BNE	g, T1, 3(PC) \
+	MOV	m_g0(S3), T1 \
+	MOV	(g_sched+gobuf_sp)(T1), X2 \
+	ADD	$-16, X2 \
+	AND	$~15, X2 \
+	MOV	X2, A1 \
+	MOV	ZERO, S4 \
+	MOVBU	runtime·iscgo(SB), T0 \
+	BNE	T0, ZERO, 6(PC) \
+	MOV	m_gsignal(S3), T0 \
+	BEQ	T0, ZERO, 4(PC) \
+	BEQ	T0, g, 3(PC) \
+	MOV	(g_stack+stack_lo)(T0), S4 \
+	MOV	g, (S4) \
+	JALR	RA, A7 \
+	MOV	0(X2), T0 \
+	MOV	8(X2), T1 \
+	MOV	S2, X2 \
+	BEQ	S4, ZERO, 2(PC) \
+	MOV	ZERO, (S4) \
+	MOV	24(X2), T2 \
+	MOV	T2, m_vdsoPC(S3) \
+	MOV	32(X2), T2 \
+	MOV	T2, m_vdsoSP(S3)
+
 // func walltime() (sec int64, nsec int32)
-TEXT runtime·walltime(SB),NOSPLIT,$24-12
+TEXT runtime·walltime(SB),NOSPLIT,$40-12
 	MOV	$0, A0 // CLOCK_REALTIME
-	MOV	X2, A1
+	MOV	runtime·__vdso_clock_gettime_sym(SB), A7
+	BEQ	A7, ZERO, fallback
+	MOV	$sec-8(FP), T2	// caller's SP
+	VDSO_CALL
+	JMP	finish
+fallback:
+	ADD	$8, X2, A1
 	MOV	$SYS_clock_gettime, A7
 	ECALL
-	MOV	0(X2), T0	// sec
-	MOV	8(X2), T1	// nsec
+	MOV	8(X2), T0	// sec
+	MOV	16(X2), T1	// nsec
+finish:
 	MOV	T0, sec+0(FP)
 	MOVW	T1, nsec+8(FP)
 	RET
 
 // func nanotime() int64
-TEXT runtime·nanotime(SB),NOSPLIT,$24-8
+TEXT runtime·nanotime(SB),NOSPLIT,$40-8
 	MOV	$1, A0 // CLOCK_MONOTONIC
-	MOV	X2, A1
+	MOV	runtime·__vdso_clock_gettime_sym(SB), A7
+	BEQ	A7, ZERO, fallback
+	MOV	$ret-8(FP), T2	// caller's SP
+	VDSO_CALL
+	JMP	finish
+fallback:
+	ADD	$8, X2, A1
 	MOV	$SYS_clock_gettime, A7
 	ECALL
-	MOV	0(X2), T0	// sec
-	MOV	8(X2), T1	// nsec
+	MOV	8(X2), T0	// sec
+	MOV	16(X2), T1	// nsec
+finish:
 	// sec is in T0, nsec in T1
 	// return nsec in T0
 	MOV	$1000000000, T2
@@ -263,7 +325,7 @@ TEXT runtime·sigfwd(SB),NOSPLIT,$0-32
 TEXT runtime·sigtramp(SB),NOSPLIT,$24
 	// this might be called in external code context,
 	// where g is not set.
-	// first save R0, because runtime·load_g will clobber it
+	// first save A0, because we use it to test iscgo
 	MOVW	A0, 8(X2)
 	MOVBU	runtime·iscgo(SB), A0
 	BEQ	A0, ZERO, 2(PC)
@@ -404,6 +466,18 @@ TEXT runtime·sched_getaffinity(SB),NOSPLIT,$-8
 	MOV	A0, ret+24(FP)
 	RET
 
+// func riscv_hwprobe(pairs unsafe.Pointer, pairCount, cpuSetSize uintptr, cpus unsafe.Pointer, flags uint32) int32
+TEXT runtime·riscv_hwprobe(SB),NOSPLIT,$-8-44
+	MOV	pairs+0(FP), A0
+	MOV	pairCount+8(FP), A1
+	MOV	cpuSetSize+16(FP), A2
+	MOV	cpus+24(FP), A3
+	MOVWU	flags+32(FP), A4
+	MOV	$SYS_riscv_hwprobe, A7
+	ECALL
+	MOVW	A0, ret+40(FP)
+	RET
+
 // func epollcreate(size int32) int32
 TEXT runtime·epollcreate(SB),NOSPLIT,$-8
 	MOV	$0, A0
@@ -0,0 +1,298 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//
+// Support for riscv64 on bare metal, in machine or supervisor mode:
+// the trap vectors, M context switches, SBI calls and the UART. The
+// memory map is in os_none_riscv.go.
+//
+
+#include "go_asm.h"
+#include "textflag.h"
+
+// The frame trapvector builds: a call frame for trap(tf, gp), then
+// the trapframe.
+#define TF	(24+trapframe_x)
+#define TFF	(24+trapframe_f)
+#define TRAPFRAME	(24+trapframe__size)
+
+// trapvector is installed in mtvec, and strapvector in stvec, so they
+// must be 4-byte aligned, which every function is. Each stashes T6 in
+// its mode's scratch register and goes on to trapsave, which saves
+// everything on the gsignal stack of the current M, calls trap, and
+// resumes from the trapframe, which trap may have changed. The
+// hardware keeps interrupts off until MRET or SRET.
+TEXT runtime·trapvector(SB),NOSPLIT,$-8
+	WORD	$0x340f9ff3	// csrrw t6, mscratch, t6
+	JMP	trapsave<>(SB)
+
+TEXT runtime·strapvector(SB),NOSPLIT,$-8
+	WORD	$0x140f9ff3	// csrrw t6, sscratch, t6
+	JMP	trapsave<>(SB)
+
+TEXT trapsave<>(SB),NOSPLIT,$-8
+	MOV	g_m(g), T6
+	MOV	m_gsignal(T6), T6
+	MOV	(g_stack+stack_hi)(T6), T6
+	ADD	$-TRAPFRAME, T6
+	MOV	X2, (TF+2*8)(T6)
+	MOV	T6, X2
+	MOV	X1, (TF+1*8)(X2)
+	MOV	X3, (TF+3*8)(X2)
+	MOV	X4, (TF+4*8)(X2)
+	MOV	X5, (TF+5*8)(X2)
+	MOV	X6, (TF+6*8)(X2)
+	MOV	X7, (TF+7*8)(X2)
+	MOV	X8, (TF+8*8)(X2)
+	MOV	X9, (TF+9*8)(X2)
+	MOV	X10, (TF+10*8)(X2)
+	MOV	X11, (TF+11*8)(X2)
+	MOV	X12, (TF+12*8)(X2)
+	MOV	X13, (TF+13*8)(X2)
+	MOV	X14, (TF+14*8)(X2)
+	MOV	X15, (TF+15*8)(X2)
+	MOV	X16, (TF+16*8)(X2)
+	MOV	X17, (TF+17*8)(X2)
+	MOV	X18, (TF+18*8)(X2)
+	MOV	X19, (TF+19*8)(X2)
+	MOV	X20, (TF+20*8)(X2)
+	MOV	X21, (TF+21*8)(X2)
+	MOV	X22, (TF+22*8)(X2)
+	MOV	X23, (TF+23*8)(X2)
+	MOV	X24, (TF+24*8)(X2)
+	MOV	X25, (TF+25*8)(X2)
+	MOV	X26, (TF+26*8)(X2)
+	MOV	X27, (TF+27*8)(X2)
+	MOV	X28, (TF+28*8)(X2)
+	MOV	X29, (TF+29*8)(X2)
+	MOV	X30, (TF+30*8)(X2)
+	MOVBU	runtime·supervisor(SB), T0
+	BNE	T0, ZERO, scsr
+	WORD	$0x34002f73	// csrr t5, mscratch
+	WORD	$0x341022f3	// csrr t0, mepc
+	WORD	$0x34202373	// csrr t1, mcause
+	WORD	$0x343023f3	// csrr t2, mtval
+	JMP	saved
+scsr:
+	WORD	$0x14002f73	// csrr t5, sscratch
+	WORD	$0x141022f3	// csrr t0, sepc
+	WORD	$0x14202373	// csrr t1, scause
+	WORD	$0x143023f3	// csrr t2, stval
+saved:
+	MOV	T5, (TF+31*8)(X2)
+	MOV	T0, (24+trapframe_pc)(X2)
+	MOV	T1, (24+trapframe_cause)(X2)
+	MOV	T2, (24+trapframe_tval)(X2)
+	MOVD	F0, (TFF+0*8)(X2)
+	MOVD	F1, (TFF+1*8)(X2)
+	MOVD	F2, (TFF+2*8)(X2)
+	MOVD	F3, (TFF+3*8)(X2)
+	MOVD	F4, (TFF+4*8)(X2)
+	MOVD	F5, (TFF+5*8)(X2)
+	MOVD	F6, (TFF+6*8)(X2)
+	MOVD	F7, (TFF+7*8)(X2)
+	MOVD	F8, (TFF+8*8)(X2)
+	MOVD	F9, (TFF+9*8)(X2)
+	MOVD	F10, (TFF+10*8)(X2)
+	MOVD	F11, (TFF+11*8)(X2)
+	MOVD	F12, (TFF+12*8)(X2)
+	MOVD	F13, (TFF+13*8)(X2)
+	MOVD	F14, (TFF+14*8)(X2)
+	MOVD	F15, (TFF+15*8)(X2)
+	MOVD	F16, (TFF+16*8)(X2)
+	MOVD	F17, (TFF+17*8)(X2)
+	MOVD	F18, (TFF+18*8)(X2)
+	MOVD	F19, (TFF+19*8)(X2)
+	MOVD	F20, (TFF+20*8)(X2)
+	MOVD	F21, (TFF+21*8)(X2)
+	MOVD	F22, (TFF+22*8)(X2)
+	MOVD	F23, (TFF+23*8)(X2)
+	MOVD	F24, (TFF+24*8)(X2)
+	MOVD	F25, (TFF+25*8)(X2)
+	MOVD	F26, (TFF+26*8)(X2)
+	MOVD	F27, (TFF+27*8)(X2)
+	MOVD	F28, (TFF+28*8)(X2)
+	MOVD	F29, (TFF+29*8)(X2)
+	MOVD	F30, (TFF+30*8)(X2)
+	MOVD	F31, (TFF+31*8)(X2)
+
+	ADD	$24, X2, T0
+	MOV	T0, 8(X2)	// tf
+	MOV	g, 16(X2)	// gp
+	MOV	g_m(g), T0
+	MOV	m_gsignal(T0), g
+	CALL	runtime·trap(SB)
+
+	MOV	(24+trapframe_pc)(X2), T0
+	MOVBU	runtime·supervisor(SB), T1
+	BNE	T1, ZERO, sepc
+	WORD	$0x34129073	// csrw mepc, t0
+	JMP	restore
+sepc:
+	WORD	$0x14129073	// csrw sepc, t0
+restore:
+	MOVD	(TFF+0*8)(X2), F0
+	MOVD	(TFF+1*8)(X2), F1
+	MOVD	(TFF+2*8)(X2), F2
+	MOVD	(TFF+3*8)(X2), F3
+	MOVD	(TFF+4*8)(X2), F4
+	MOVD	(TFF+5*8)(X2), F5
+	MOVD	(TFF+6*8)(X2), F6
+	MOVD	(TFF+7*8)(X2), F7
+	MOVD	(TFF+8*8)(X2), F8
+	MOVD	(TFF+9*8)(X2), F9
+	MOVD	(TFF+10*8)(X2), F10
+	MOVD	(TFF+11*8)(X2), F11
+	MOVD	(TFF+12*8)(X2), F12
+	MOVD	(TFF+13*8)(X2), F13
+	MOVD	(TFF+14*8)(X2), F14
+	MOVD	(TFF+15*8)(X2), F15
+	MOVD	(TFF+16*8)(X2), F16
+	MOVD	(TFF+17*8)(X2), F17
+	MOVD	(TFF+18*8)(X2), F18
+	MOVD	(TFF+19*8)(X2), F19
+	MOVD	(TFF+20*8)(X2), F20
+	MOVD	(TFF+21*8)(X2), F21
+	MOVD	(TFF+22*8)(X2), F22
+	MOVD	(TFF+23*8)(X2), F23
+	MOVD	(TFF+24*8)(X2), F24
+	MOVD	(TFF+25*8)(X2), F25
+	MOVD	(TFF+26*8)(X2), F26
+	MOVD	(TFF+27*8)(X2), F27
+	MOVD	(TFF+28*8)(X2), F28
+	MOVD	(TFF+29*8)(X2), F29
+	MOVD	(TFF+30*8)(X2), F30
+	MOVD	(TFF+31*8)(X2), F31
+	MOV	(TF+1*8)(X2), X1
+	MOV	(TF+3*8)(X2), X3
+	MOV	(TF+4*8)(X2), X4
+	MOV	(TF+5*8)(X2), X5
+	MOV	(TF+6*8)(X2), X6
+	MOV	(TF+7*8)(X2), X7
+	MOV	(TF+8*8)(X2), X8
+	MOV	(TF+9*8)(X2), X9
+	MOV	(TF+10*8)(X2), X10
+	MOV	(TF+11*8)(X2), X11
+	MOV	(TF+12*8)(X2), X12
+	MOV	(TF+13*8)(X2), X13
+	MOV	(TF+14*8)(X2), X14
+	MOV	(TF+15*8)(X2), X15
+	MOV	(TF+16*8)(X2), X16
+	MOV	(TF+17*8)(X2), X17
+	MOV	(TF+18*8)(X2), X18
+	MOV	(TF+19*8)(X2), X19
+	MOV	(TF+20*8)(X2), X20
+	MOV	(TF+21*8)(X2), X21
+	MOV	(TF+22*8)(X2), X22
+	MOV	(TF+23*8)(X2), X23
+	MOV	(TF+24*8)(X2), X24
+	MOV	(TF+25*8)(X2), X25
+	MOV	(TF+26*8)(X2), X26
+	MOV	(TF+27*8)(X2), X27
+	MOV	(TF+28*8)(X2), X28
+	MOV	(TF+29*8)(X2), X29
+	MOV	(TF+30*8)(X2), X30
+	MOVBU	runtime·supervisor(SB), X31
+	BNE	X31, ZERO, sret
+	MOV	(TF+31*8)(X2), X31
+	MOV	(TF+2*8)(X2), X2
+	MRET
+sret:
+	MOV	(TF+31*8)(X2), X31
+	MOV	(TF+2*8)(X2), X2
+	SRET
+
+// func switchcontext(from, to *threadContext)
+TEXT runtime·switchcontext(SB),NOSPLIT,$-8-16
+	MOV	from+0(FP), A0
+	MOV	to+8(FP), A1
+	MOV	RA, threadContext_pc(A0)
+	MOV	X2, threadContext_sp(A0)
+	MOV	g, threadContext_g(A0)
+	MOV	threadContext_pc(A1), RA
+	MOV	threadContext_sp(A1), X2
+	MOV	threadContext_g(A1), g
+	RET
+
+TEXT runtime·mstart_none(SB),NOSPLIT,$0
+	CALL	runtime·mstart(SB)
+	WORD	$0	// crash if reached
+	RET
+
+// func enableInterrupts()
+TEXT runtime·enableInterrupts(SB),NOSPLIT,$-8-0
+	MOVBU	runtime·supervisor(SB), T0
+	BNE	T0, ZERO, smode
+	MOV	$const_mieMTIE, T0
+	WORD	$0x3042a073	// csrs mie, t0
+	MOV	$const_mstatusMIE, T0
+	WORD	$0x3002a073	// csrs mstatus, t0
+	RET
+smode:
+	MOV	$const_sieSTIE, T0
+	WORD	$0x1042a073	// csrs sie, t0
+	MOV	$const_sstatusSIE, T0
+	WORD	$0x1002a073	// csrs sstatus, t0
+	RET
+
+// func disableInterrupts()
+TEXT runtime·disableInterrupts(SB),NOSPLIT,$-8-0
+	MOVBU	runtime·supervisor(SB), T0
+	BNE	T0, ZERO, smode
+	MOV	$const_mstatusMIE, T0
+	WORD	$0x3002b073	// csrc mstatus, t0
+	RET
+smode:
+	MOV	$const_sstatusSIE, T0
+	WORD	$0x1002b073	// csrc sstatus, t0
+	RET
+
+// func wfi()
+TEXT runtime·wfi(SB),NOSPLIT,$-8-0
+	WFI
+	RET
+
+// func sbicall(ext, fid, a0, a1 uintptr) int
+// Calls the SBI firmware and returns its error code.
+TEXT runtime·sbicall(SB),NOSPLIT,$-8-40
+	MOV	ext+0(FP), A7
+	MOV	fid+8(FP), A6
+	MOV	a0+16(FP), A0
+	MOV	a1+24(FP), A1
+	ECALL
+	MOV	A0, ret+32(FP)
+	RET
+
+// func write(fd uintptr, p unsafe.Pointer, n int32) int32
+// Everything goes to the UART, whatever the fd.
+TEXT runtime·write(SB),NOSPLIT,$-8-28
+	MOV	p+8(FP), A1
+	MOVW	n+16(FP), A2
+	MOVW	A2, ret+24(FP)
+	MOV	$const_uartBase, A3
+loop:
+	BEQ	A2, ZERO, done
+wait:
+	MOVBU	5(A3), A4	// LSR
+	AND	$0x20, A4	// THR empty
+	BEQ	A4, ZERO, wait
+	MOVBU	0(A1), A4
+	MOVB	A4, 0(A3)	// THR
+	ADD	$1, A1
+	ADD	$-1, A2
+	JMP	loop
+done:
+	RET
+
+// func walltime() (sec int64, nsec int32)
+// There is no real-time clock, so the epoch is when the hart booted.
+TEXT runtime·walltime(SB),NOSPLIT,$8-12
+	CALL	runtime·nanotime(SB)
+	MOV	8(X2), T0
+	MOV	$1000000000, T1
+	DIV	T1, T0, T2
+	REM	T1, T0, T3
+	MOV	T2, sec+0(FP)
+	MOVW	T3, nsec+8(FP)
+	RET
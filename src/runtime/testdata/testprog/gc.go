@@ -9,6 +9,7 @@ import (
 	"os"
 	"runtime"
 	"runtime/debug"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -17,6 +18,7 @@ func init() {
 	register("GCFairness", GCFairness)
 	register("GCFairness2", GCFairness2)
 	register("GCSys", GCSys)
+	register("GCStackBarriers", GCStackBarriers)
 }
 
 func GCSys() {
@@ -121,3 +123,31 @@ func GCFairness2() {
 	}
 	fmt.Println("OK")
 }
+
+func GCStackBarriers() {
+	const depth = 1000
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got, want := stackBarrierSum(depth), depth*(depth+1)/2; got != want {
+					fmt.Printf("stackBarrierSum(%d) = %d, want %d\n", depth, got, want)
+					os.Exit(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println("OK")
+}
+
+//go:noinline
+func stackBarrierSum(n int) int {
+	if n == 0 {
+		workthegc()
+		return 0
+	}
+	return n + stackBarrierSum(n-1)
+}
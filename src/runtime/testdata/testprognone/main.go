@@ -0,0 +1,131 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Testprognone is booted on bare metal (GOOS=none) by the runtime's
+// TestBareMetal tests, through go_$GOOS_$GOARCH_exec.
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+var cmds = map[string]func(){
+	"Args":       Args,
+	"Goroutines": Goroutines,
+	"Preempt":    Preempt,
+	"Sleep":      Sleep,
+	"Fault":      Fault,
+	"Panic":      Panic,
+	"Exit":       Exit,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		println("usage: " + os.Args[0] + " name-of-test")
+		return
+	}
+	f := cmds[os.Args[1]]
+	if f == nil {
+		println("unknown function: " + os.Args[1])
+		return
+	}
+	f()
+}
+
+func Args() {
+	fmt.Println(strings.Join(os.Args[2:], ","))
+}
+
+var sink []byte
+
+func Goroutines() {
+	var wg sync.WaitGroup
+	c := make(chan int)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				sink = make([]byte, 1000+j)
+			}
+			c <- i
+		}(i)
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += <-c
+	}
+	wg.Wait()
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	if sum != 28 || ms.NumGC == 0 {
+		fmt.Printf("sum %d, %d GCs\n", sum, ms.NumGC)
+		return
+	}
+	fmt.Println("OK")
+}
+
+// spin is not a leaf, so it checks for preemption on entry.
+func spin(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return spin(n-1) + 1
+}
+
+// Preempt checks that the timer interrupt preempts a goroutine that
+// never blocks, as there is only the one P.
+func Preempt() {
+	var stop uint32
+	go func() {
+		for stop == 0 {
+			spin(8)
+		}
+	}()
+	runtime.Gosched()
+	stop = 1
+	fmt.Println("OK")
+}
+
+func Sleep() {
+	t0 := time.Now()
+	time.Sleep(50 * time.Millisecond)
+	if d := time.Since(t0); d < 50*time.Millisecond || d > 5*time.Second {
+		fmt.Println("slept", d)
+		return
+	}
+	select {
+	case <-time.After(10 * time.Millisecond):
+	}
+	fmt.Println("OK")
+}
+
+type T struct{ x int }
+
+func Fault() {
+	defer func() {
+		err, ok := recover().(runtime.Error)
+		if !ok || !strings.Contains(err.Error(), "nil pointer dereference") {
+			fmt.Println("recovered", err)
+			return
+		}
+		fmt.Println("OK")
+	}()
+	var p *T
+	println(p.x)
+}
+
+func Panic() {
+	panic("bare metal")
+}
+
+func Exit() {
+	os.Exit(7)
+}
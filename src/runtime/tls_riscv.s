@@ -7,12 +7,33 @@
 #include "funcdata.h"
 #include "textflag.h"
 
+// g lives in S11, which C code preserves across calls but which may hold
+// anything while C code runs. When cgo is in use, g is also kept in the
+// thread-local runtime·tls_g, at its offset from the thread pointer TP.
+
 // If !iscgo, this is a no-op.
 //
-// NOTE: mcall() assumes this clobbers only R23 (REGTMP).
-// FIXME: cgo
+// NOTE: mcall() assumes this clobbers only TMP (T6).
 TEXT runtime·save_g(SB),NOSPLIT,$-8-0
+	MOVBU	runtime·iscgo(SB), TMP
+	BEQ	TMP, ZERO, nocgo
+
+	MOV	runtime·tls_g(SB), TMP
+	ADD	TP, TMP
+	MOV	g, (TMP)
+
+nocgo:
 	RET
 
 TEXT runtime·load_g(SB),NOSPLIT,$-8-0
+	MOVBU	runtime·iscgo(SB), TMP
+	BEQ	TMP, ZERO, nocgo
+
+	MOV	runtime·tls_g(SB), TMP
+	ADD	TP, TMP
+	MOV	(TMP), g
+
+nocgo:
 	RET
+
+GLOBL runtime·tls_g+0(SB), TLSBSS, $8
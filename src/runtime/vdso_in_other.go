@@ -0,0 +1,12 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build linux,!amd64,!riscv !linux
+
+package runtime
+
+// inVDSOPage reports whether pc is on the vDSO page.
+func inVDSOPage(pc uintptr) bool {
+	return false
+}
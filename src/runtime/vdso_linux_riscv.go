@@ -0,0 +1,16 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtime
+
+// The RISC-V vDSO first appeared in Linux 4.15.
+var vdso_linux_version = version_key{"LINUX_4.15", 0xae77f75}
+
+var sym_keys = []symbol_key{
+	{"__vdso_clock_gettime", 0xd35ec75, &__vdso_clock_gettime_sym},
+}
+
+// There is no vsyscall page; nanotime and walltime make the
+// clock_gettime system call if the vDSO lacks __vdso_clock_gettime.
+var __vdso_clock_gettime_sym uintptr = 0
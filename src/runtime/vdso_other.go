@@ -4,6 +4,7 @@
 
 // +build !linux
 // +build !darwin
+// +build !none
 
 package runtime
 
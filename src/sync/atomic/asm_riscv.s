@@ -34,11 +34,8 @@
 #define AMODSC(op,rd,rs1,rs2) WORD $0x0600302f+rd<<7+rs1<<15+rs2<<20+op<<27
 #define ADD_ 0
 #define SWAP_ 1
-#define LR_ 2
-#define SC_ 3
 #define OR_ 8
 #define AND_ 12
-#define FENCE WORD $0x0ff0000f
 
 TEXT ·SwapInt32(SB),NOSPLIT,$0-20
 	JMP	·SwapUint32(SB)
@@ -74,9 +71,9 @@ TEXT ·CompareAndSwapUint32(SB),NOSPLIT,$0-17
 	MOVW	old+8(FP), A1
 	MOVW	new+12(FP), A2
 again:
-	AMOWSC(LR_,13,10,0)	// lr.w.sc a3,(a0)
+	LRW	(A0), A3
 	BNE	A3, A1, fail
-	AMOWSC(SC_,14,10,12)	// sc.w.sc a0,a2,(a0)
+	SCW	A2, (A0), A4
 	BNE	A4, ZERO, again // a4=0 if sc succeeded
 	MOV	$1, A0
 	MOVB	A0, ret+16(FP)
@@ -90,9 +87,9 @@ TEXT ·CompareAndSwapUint64(SB),NOSPLIT,$0-25
 	MOV	old+8(FP), A1
 	MOV	new+16(FP), A2
 again:
-	AMODSC(LR_,13,10,0)
+	LRD	(A0), A3
 	BNE	A3, A1, fail
-	AMODSC(SC_,14,10,12)
+	SCD	A2, (A0), A4
 	BNE	A4, ZERO, again
 	MOV	$1, A0
 	MOVB	A0, ret+24(FP)
@@ -137,13 +134,13 @@ TEXT ·LoadInt64(SB),NOSPLIT,$0-16
 
 TEXT ·LoadUint32(SB),NOSPLIT,$0-12
 	MOV	ptr+0(FP), A0
-	AMOWSC(LR_,10,10,0)
+	LRW	(A0), A0
 	MOVW	A0, ret+8(FP)
 	RET
 
 TEXT ·LoadUint64(SB),NOSPLIT,$0-16
 	MOV	ptr+0(FP), A0
-	AMODSC(LR_,10,10,0)
+	LRD	(A0), A0
 	MOV	A0, ret+8(FP)
 	RET
 
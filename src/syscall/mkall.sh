@@ -160,6 +160,12 @@ freebsd_arm)
 	# API consistent between platforms.
 	mktypes="GOARCH=$GOARCH go tool cgo -godefs -- -fsigned-char"
 	;;
+freebsd_riscv)
+	mkerrors="$mkerrors"
+	mksyscall="./mksyscall.pl -freebsd12"
+	mksysnum="curl -s 'http://svn.freebsd.org/base/stable/12/sys/kern/syscalls.master' | ./mksysnum_freebsd.pl"
+	mktypes="GOARCH=$GOARCH go tool cgo -godefs"
+	;;
 linux_386)
 	mkerrors="$mkerrors -m32"
 	mksyscall="./mksyscall.pl -l32"
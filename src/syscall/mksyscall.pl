@@ -28,6 +28,7 @@ my $plan9 = 0;
 my $openbsd = 0;
 my $netbsd = 0;
 my $dragonfly = 0;
+my $freebsd12 = 0;
 my $nacl = 0;
 my $arm = 0; # 64-bit value should use (even, odd)-pair
 
@@ -54,6 +55,10 @@ if($ARGV[0] eq "-dragonfly") {
 	$dragonfly = 1;
 	shift;
 }
+if($ARGV[0] eq "-freebsd12") {
+	$freebsd12 = 1;
+	shift;
+}
 if($ARGV[0] eq "-nacl") {
 	$nacl = 1;
 	shift;
@@ -107,6 +112,10 @@ while(<>) {
 	}
 	my ($func, $in, $out, $sysname) = ($2, $3, $4, $5);
 
+	# FreeBSD 12 replaced these calls, and ports that first appeared
+	# there, such as riscv, do not have them.
+	next if $freebsd12 && $func =~ /^(pipe|Getdirentries|Lstat|Mknod|Stat)$/;
+
 	# Split argument lists on comma.
 	my @in = parseparamlist($in);
 	my @out = parseparamlist($out);
@@ -0,0 +1,87 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package syscall
+
+import "unsafe"
+
+func setTimespec(sec, nsec int64) Timespec {
+	return Timespec{Sec: sec, Nsec: nsec}
+}
+
+func setTimeval(sec, usec int64) Timeval {
+	return Timeval{Sec: sec, Usec: usec}
+}
+
+func SetKevent(k *Kevent_t, fd, mode, flags int) {
+	k.Ident = uint64(fd)
+	k.Filter = int16(mode)
+	k.Flags = uint16(flags)
+}
+
+func (iov *Iovec) SetLen(length int) {
+	iov.Len = uint64(length)
+}
+
+func (msghdr *Msghdr) SetControllen(length int) {
+	msghdr.Controllen = uint32(length)
+}
+
+func (cmsg *Cmsghdr) SetLen(length int) {
+	cmsg.Len = uint32(length)
+}
+
+func sendfile(outfd int, infd int, offset *int64, count int) (written int, err error) {
+	var writtenOut uint64 = 0
+	_, _, e1 := Syscall9(SYS_SENDFILE, uintptr(infd), uintptr(outfd), uintptr(*offset), uintptr(count), 0, uintptr(unsafe.Pointer(&writtenOut)), 0, 0, 0)
+
+	written = int(writtenOut)
+
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+// FreeBSD/riscv has only the FreeBSD 12 system calls, which use 64-bit
+// inode numbers, so mksyscall.pl -freebsd12 leaves out the older calls
+// that syscall_freebsd.go declares. They are implemented here instead.
+
+//sysnb	pipe2(p *[2]_C_int, flags int) (err error)
+
+func pipe() (r int, w int, err error) {
+	var p [2]_C_int
+	err = pipe2(&p, 0)
+	return int(p[0]), int(p[1]), err
+}
+
+func Stat(path string, stat *Stat_t) (err error) {
+	return fstatat(_AT_FDCWD, path, stat, 0)
+}
+
+func Lstat(path string, stat *Stat_t) (err error) {
+	return fstatat(_AT_FDCWD, path, stat, _AT_SYMLINK_NOFOLLOW)
+}
+
+func Mknod(path string, mode uint32, dev int) (err error) {
+	return mknodat(_AT_FDCWD, path, mode, uint64(dev))
+}
+
+func Getdirentries(fd int, buf []byte, basep *uintptr) (n int, err error) {
+	var base uint64
+	if basep != nil {
+		base = uint64(*basep)
+	}
+	n, err = getdirentries(fd, buf, &base)
+	if basep != nil {
+		*basep = uintptr(base)
+	}
+	return
+}
+
+//sys	fstatat(fd int, path string, stat *Stat_t, flags int) (err error)
+//sys	getdirentries(fd int, buf []byte, basep *uint64) (n int, err error)
+//sys	mknodat(fd int, path string, mode uint32, dev uint64) (err error)
+
+func Syscall9(num, a1, a2, a3, a4, a5, a6, a7, a8, a9 uintptr) (r1, r2 uintptr, err Errno)
@@ -0,0 +1,482 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// A GOOS=none program has no kernel beneath it. Only the console and
+// pipes are provided: reads from the console see end of file, and
+// writes go to the runtime's console. There is no file system, no
+// processes and no network; those calls fail with ENOENT or ENOSYS.
+
+package syscall
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// Implemented in the runtime package.
+func runtime_consoleWrite(p unsafe.Pointer, n int32) int32
+func runtime_exit(code int32)
+
+const direntSize = 8 + 8 + 2 + 256
+
+type Dirent struct {
+	Ino    int64
+	Off    int64
+	Reclen uint16
+	Name   [256]byte
+}
+
+func direntIno(buf []byte) (uint64, bool) {
+	return readInt(buf, unsafe.Offsetof(Dirent{}.Ino), unsafe.Sizeof(Dirent{}.Ino))
+}
+
+func direntReclen(buf []byte) (uint64, bool) {
+	return readInt(buf, unsafe.Offsetof(Dirent{}.Reclen), unsafe.Sizeof(Dirent{}.Reclen))
+}
+
+func direntNamlen(buf []byte) (uint64, bool) {
+	reclen, ok := direntReclen(buf)
+	if !ok {
+		return 0, false
+	}
+	return reclen - uint64(unsafe.Offsetof(Dirent{}.Name)), true
+}
+
+const PathMax = 256
+
+// An Errno is an unsigned number describing an error condition.
+// It implements the error interface. The zero Errno is by convention
+// a non-error, so code to convert from Errno to error should use:
+//	err = nil
+//	if errno != 0 {
+//		err = errno
+//	}
+type Errno uintptr
+
+func (e Errno) Error() string {
+	if 0 <= int(e) && int(e) < len(errors) {
+		s := errors[e]
+		if s != "" {
+			return s
+		}
+	}
+	return "errno " + itoa(int(e))
+}
+
+func (e Errno) Temporary() bool {
+	return e == EINTR || e == EMFILE || e.Timeout()
+}
+
+func (e Errno) Timeout() bool {
+	return e == EAGAIN || e == EWOULDBLOCK || e == ETIMEDOUT
+}
+
+// A Signal is a number describing a process signal.
+// It implements the os.Signal interface.
+type Signal int
+
+const (
+	_ Signal = iota
+	SIGHUP
+	SIGINT
+	SIGQUIT
+	SIGILL
+	SIGTRAP
+	SIGABRT
+	SIGBUS
+	SIGFPE
+	SIGKILL
+	SIGUSR1
+	SIGSEGV
+	SIGUSR2
+	SIGPIPE
+	SIGALRM
+	SIGTERM
+	_
+	SIGCHLD
+)
+
+func (s Signal) Signal() {}
+
+func (s Signal) String() string {
+	if 0 <= s && int(s) < len(signals) {
+		str := signals[s]
+		if str != "" {
+			return str
+		}
+	}
+	return "signal " + itoa(int(s))
+}
+
+var signals = [...]string{
+	SIGHUP:  "hangup",
+	SIGINT:  "interrupt",
+	SIGQUIT: "quit",
+	SIGILL:  "illegal instruction",
+	SIGTRAP: "trace/breakpoint trap",
+	SIGABRT: "aborted",
+	SIGBUS:  "bus error",
+	SIGFPE:  "floating point exception",
+	SIGKILL: "killed",
+	SIGUSR1: "user defined signal 1",
+	SIGSEGV: "segmentation fault",
+	SIGUSR2: "user defined signal 2",
+	SIGPIPE: "broken pipe",
+	SIGALRM: "alarm clock",
+	SIGTERM: "terminated",
+	SIGCHLD: "child exited",
+}
+
+// File system
+
+const (
+	Stdin  = 0
+	Stdout = 1
+	Stderr = 2
+)
+
+const (
+	O_RDONLY  = 0
+	O_WRONLY  = 1
+	O_RDWR    = 2
+	O_ACCMODE = 3
+
+	O_CREAT    = 0100
+	O_CREATE   = O_CREAT // for ken
+	O_TRUNC    = 01000
+	O_APPEND   = 02000
+	O_EXCL     = 0200
+	O_NONBLOCK = 04000
+	O_NDELAY   = O_NONBLOCK
+	O_SYNC     = 010000
+	O_FSYNC    = O_SYNC
+	O_ASYNC    = 020000
+
+	O_CLOEXEC = 0
+
+	FD_CLOEXEC = 1
+)
+
+const (
+	S_IFMT   = 0170000
+	S_IFSOCK = 0140000
+	S_IFLNK  = 0120000
+	S_IFREG  = 0100000
+	S_IFBLK  = 0060000
+	S_IFDIR  = 0040000
+	S_IFCHR  = 0020000
+	S_IFIFO  = 0010000
+
+	S_ISUID = 0004000
+	S_ISGID = 0002000
+	S_ISVTX = 0001000
+
+	S_IREAD  = 0400
+	S_IWRITE = 0200
+	S_IEXEC  = 0100
+
+	S_IRWXU = 0700
+	S_IRUSR = 0400
+	S_IWUSR = 0200
+	S_IXUSR = 0100
+
+	S_IRWXG = 070
+	S_IRGRP = 040
+	S_IWGRP = 020
+	S_IXGRP = 010
+
+	S_IRWXO = 07
+	S_IROTH = 04
+	S_IWOTH = 02
+	S_IXOTH = 01
+)
+
+type Stat_t struct {
+	Dev       int64
+	Ino       uint64
+	Mode      uint32
+	Nlink     uint32
+	Uid       uint32
+	Gid       uint32
+	Rdev      int64
+	Size      int64
+	Blksize   int32
+	Blocks    int32
+	Atime     int64
+	AtimeNsec int64
+	Mtime     int64
+	MtimeNsec int64
+	Ctime     int64
+	CtimeNsec int64
+}
+
+type Timespec struct {
+	Sec  int64
+	Nsec int64
+}
+
+type Timeval struct {
+	Sec  int64
+	Usec int64
+}
+
+func setTimespec(sec, nsec int64) Timespec {
+	return Timespec{Sec: sec, Nsec: nsec}
+}
+
+func setTimeval(sec, usec int64) Timeval {
+	return Timeval{Sec: sec, Usec: usec}
+}
+
+// Files
+
+// A GOOS=none program has the console as descriptors 0, 1 and 2 and
+// whatever pipes it makes, which package testing needs in order to
+// capture the output of examples. Pipes are kept in memory; reading
+// an empty one blocks the goroutine until it is written or closed.
+
+type pipe struct {
+	mu      sync.Mutex
+	cond    sync.Cond
+	buf     []byte
+	rclosed bool
+	wclosed bool
+}
+
+type pipeEnd struct {
+	*pipe
+	write bool
+}
+
+var (
+	filesMu sync.Mutex
+	files   = map[int]*pipeEnd{}
+)
+
+func isConsole(fd int) bool { return fd == Stdin || fd == Stdout || fd == Stderr }
+
+func lookup(fd int) (*pipeEnd, error) {
+	filesMu.Lock()
+	f := files[fd]
+	filesMu.Unlock()
+	if f == nil {
+		return nil, EBADF
+	}
+	return f, nil
+}
+
+func Pipe(fd []int) error {
+	if len(fd) != 2 {
+		return EINVAL
+	}
+	p := new(pipe)
+	p.cond.L = &p.mu
+	filesMu.Lock()
+	defer filesMu.Unlock()
+	for i, n := 0, 3; i < 2; n++ {
+		if files[n] == nil {
+			files[n] = &pipeEnd{p, i == 1}
+			fd[i] = n
+			i++
+		}
+	}
+	return nil
+}
+
+func Read(fd int, b []byte) (int, error) {
+	if isConsole(fd) {
+		return 0, nil
+	}
+	f, err := lookup(fd)
+	if err != nil {
+		return 0, err
+	}
+	if f.write {
+		return 0, EBADF
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.buf) == 0 && !f.wclosed && len(b) > 0 {
+		f.cond.Wait()
+	}
+	n := copy(b, f.buf)
+	f.buf = f.buf[n:]
+	return n, nil
+}
+
+func Write(fd int, b []byte) (int, error) {
+	if isConsole(fd) {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		return int(runtime_consoleWrite(unsafe.Pointer(&b[0]), int32(len(b)))), nil
+	}
+	f, err := lookup(fd)
+	if err != nil {
+		return 0, err
+	}
+	if !f.write {
+		return 0, EBADF
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rclosed {
+		return 0, EPIPE
+	}
+	f.buf = append(f.buf, b...)
+	f.cond.Broadcast()
+	return len(b), nil
+}
+
+func Close(fd int) error {
+	if isConsole(fd) {
+		return nil
+	}
+	filesMu.Lock()
+	f := files[fd]
+	delete(files, fd)
+	filesMu.Unlock()
+	if f == nil {
+		return EBADF
+	}
+	f.mu.Lock()
+	if f.write {
+		f.wclosed = true
+	} else {
+		f.rclosed = true
+	}
+	f.cond.Broadcast()
+	f.mu.Unlock()
+	return nil
+}
+
+func Fstat(fd int, st *Stat_t) error {
+	mode := uint32(S_IFCHR | 0600)
+	if !isConsole(fd) {
+		if _, err := lookup(fd); err != nil {
+			return err
+		}
+		mode = S_IFIFO | 0600
+	}
+	*st = Stat_t{Mode: mode, Nlink: 1, Blksize: 1}
+	return nil
+}
+
+// checkFd reports EBADF for a descriptor that is not open.
+func checkFd(fd int) error {
+	if isConsole(fd) {
+		return nil
+	}
+	_, err := lookup(fd)
+	return err
+}
+
+func Pread(fd int, b []byte, offset int64) (int, error) {
+	if err := checkFd(fd); err != nil {
+		return 0, err
+	}
+	return 0, ESPIPE
+}
+
+func Pwrite(fd int, b []byte, offset int64) (int, error) {
+	if err := checkFd(fd); err != nil {
+		return 0, err
+	}
+	return 0, ESPIPE
+}
+
+func Seek(fd int, offset int64, whence int) (int64, error) {
+	if err := checkFd(fd); err != nil {
+		return 0, err
+	}
+	return 0, ESPIPE
+}
+
+func CloseOnExec(fd int) {}
+
+func SetNonblock(fd int, nonblocking bool) error { return checkFd(fd) }
+
+func Fsync(fd int) error { return checkFd(fd) }
+
+func Exit(code int) {
+	runtime_exit(int32(code))
+}
+
+// There is no file system.
+
+func Open(path string, mode int, perm uint32) (fd int, err error) { return -1, ENOENT }
+func Stat(path string, st *Stat_t) error                          { return ENOENT }
+func Lstat(path string, st *Stat_t) error                         { return ENOENT }
+func ReadDirent(fd int, buf []byte) (int, error)                  { return 0, EBADF }
+func Readlink(path string, buf []byte) (n int, err error)         { return 0, ENOENT }
+func Mkdir(path string, perm uint32) error                        { return EROFS }
+func Rmdir(path string) error                                     { return ENOENT }
+func Unlink(path string) error                                    { return ENOENT }
+func Rename(from, to string) error                                { return ENOENT }
+func Link(path, link string) error                                { return ENOENT }
+func Symlink(path, link string) error                             { return EROFS }
+func Chdir(path string) error                                     { return ENOENT }
+func Fchdir(fd int) error                                         { return ENOTDIR }
+func Chmod(path string, mode uint32) error                        { return ENOENT }
+func Fchmod(fd int, mode uint32) error                            { return EINVAL }
+func Chown(path string, uid, gid int) error                       { return ENOENT }
+func Fchown(fd int, uid, gid int) error                           { return EINVAL }
+func Lchown(path string, uid, gid int) error                      { return ENOENT }
+func Truncate(path string, length int64) error                    { return ENOENT }
+func Ftruncate(fd int, length int64) error                        { return EINVAL }
+func UtimesNano(path string, ts []Timespec) error                 { return ENOENT }
+func Dup(fd int) (int, error)                                     { return -1, ENOSYS }
+
+const ImplementsGetwd = true
+
+func Getwd() (wd string, err error) { return "/", nil }
+
+// Processes
+// There is only the one - just enough for package os.
+
+var ForkLock sync.RWMutex
+
+type WaitStatus uint32
+
+func (w WaitStatus) Exited() bool       { return false }
+func (w WaitStatus) ExitStatus() int    { return 0 }
+func (w WaitStatus) Signaled() bool     { return false }
+func (w WaitStatus) Signal() Signal     { return 0 }
+func (w WaitStatus) CoreDump() bool     { return false }
+func (w WaitStatus) Stopped() bool      { return false }
+func (w WaitStatus) Continued() bool    { return false }
+func (w WaitStatus) StopSignal() Signal { return 0 }
+func (w WaitStatus) TrapCause() int     { return 0 }
+
+type Rusage struct {
+	Utime Timeval
+	Stime Timeval
+}
+
+type ProcAttr struct {
+	Dir   string
+	Env   []string
+	Files []uintptr
+	Sys   *SysProcAttr
+}
+
+type SysProcAttr struct {
+}
+
+func Getegid() int                      { return 0 }
+func Geteuid() int                      { return 0 }
+func Getgid() int                       { return 0 }
+func Getgroups() ([]int, error)         { return []int{}, nil }
+func Getppid() int                      { return 0 }
+func Getpid() int                       { return 1 }
+func Getuid() int                       { return 0 }
+func Kill(pid int, signum Signal) error { return ENOSYS }
+func StartProcess(argv0 string, argv []string, attr *ProcAttr) (pid int, handle uintptr, err error) {
+	return 0, 0, ENOSYS
+}
+func Wait4(pid int, wstatus *WaitStatus, options int, rusage *Rusage) (wpid int, err error) {
+	return 0, ENOSYS
+}
+
+func Sysctl(key string) (string, error) { return "", ENOSYS }
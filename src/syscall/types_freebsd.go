@@ -60,6 +60,11 @@ struct sockaddr_any {
 	char pad[sizeof(union sockaddr_all) - sizeof(struct sockaddr)];
 };
 
+// FreeBSD/riscv first appeared in FreeBSD 12, after the switch to
+// 64-bit inode numbers, so it has only the new stat layout.
+#ifdef __riscv
+#define stat8 stat
+#else
 // This structure is a duplicate of stat on FreeBSD 8-STABLE.
 // See /usr/include/sys/stat.h.
 struct stat8 {
@@ -103,6 +108,7 @@ struct stat8 {
 	unsigned int :(8 / 2) * (16 - (int)sizeof(struct __timespec));
 #endif
 };
+#endif
 
 // This structure is a duplicate of if_data on FreeBSD 8-STABLE.
 // See /usr/include/net/if.h.
@@ -130,7 +136,10 @@ struct if_data8 {
 	u_long  ifi_iqdrops;
 	u_long  ifi_noproto;
 	u_long  ifi_hwassist;
+// FreeBSD 12 turned these into unions; keep the old names.
+#undef ifi_epoch
 	time_t  ifi_epoch;
+#undef ifi_lastchange
 	struct  timeval ifi_lastchange;
 };
 
@@ -340,3 +349,10 @@ type BpfZbufHeader C.struct_bpf_zbuf_header
 // Terminal handling
 
 type Termios C.struct_termios
+
+// Misc
+
+const (
+	_AT_FDCWD            = C.AT_FDCWD
+	_AT_SYMLINK_NOFOLLOW = C.AT_SYMLINK_NOFOLLOW
+)